@@ -1,11 +1,26 @@
 package validator
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"math/bits"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type Field string
@@ -14,25 +29,131 @@ type ErrorMsg string
 
 type CustomErrors map[Field]map[Rule]ErrorMsg
 
+var ErrInvalidRule = errors.New("validate: invalid rule")
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
 type ValidationError struct {
-	Field   string
-	Message ErrorMsg
+	Field    string
+	Message  ErrorMsg
+	Rule     Rule
+	Param    string
+	Severity Severity
 }
 
 func (e *ValidationError) Error() string {
 	return fmt.Sprintf("Field '%s' validation failed: %s", e.Field, e.Message)
 }
 
+func newRuleError(rule Rule, param string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Message: ErrorMsg(fmt.Sprintf(format, args...)),
+		Rule:    rule,
+		Param:   param,
+	}
+}
+
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) FilterBySeverity(severity Severity) ValidationErrors {
+	var filtered ValidationErrors
+	for _, err := range e {
+		if severityOf(err) == severity {
+			filtered = append(filtered, err)
+		}
+	}
+	return filtered
+}
+
+func severityOf(err error) Severity {
+	if validationErr, ok := err.(*ValidationError); ok && validationErr.Severity != "" {
+		return validationErr.Severity
+	}
+	return SeverityError
+}
+
+type RuleFunc func(field reflect.Value, param string) error
+
 type Validator struct {
-	customErrors CustomErrors
+	tagName             string
+	customErrors        CustomErrors
+	caseInsensitiveKeys bool
+	nilOnlyCollections  bool
+	combineFieldErrors  bool
+	strictTags          bool
+	fieldNameFunc       func(reflect.StructField) string
+	keySets             map[string]map[string]struct{}
+	wordSets            map[string]map[string]struct{}
+	rules               map[string]RuleFunc
+	normalizers         map[string]func(string) string
+	accessors           map[reflect.Type]func(interface{}) map[string]interface{}
+	severities          map[Rule]Severity
+	patterns            map[string]*regexp.Regexp
+	switches            map[string]map[string]string
+	thresholds          map[string]mapThreshold
+	enums               map[reflect.Type]map[int64]struct{}
+	holidays            map[string]struct{}
+	now                 func() time.Time
+	paths               *pathFilter
 }
 
 func New() *Validator {
 	return &Validator{
+		tagName:      "validate",
 		customErrors: make(CustomErrors),
+		keySets:      make(map[string]map[string]struct{}),
+		wordSets:     make(map[string]map[string]struct{}),
+		rules:        make(map[string]RuleFunc),
+		normalizers:  make(map[string]func(string) string),
+		accessors:    make(map[reflect.Type]func(interface{}) map[string]interface{}),
+		severities:   make(map[Rule]Severity),
+		patterns:     make(map[string]*regexp.Regexp),
+		switches:     make(map[string]map[string]string),
+		thresholds:   make(map[string]mapThreshold),
+		enums:        make(map[reflect.Type]map[int64]struct{}),
+		holidays:     make(map[string]struct{}),
+		now:          time.Now,
 	}
 }
 
+type Combined struct {
+	validators []*Validator
+}
+
+func Combine(validators ...*Validator) *Combined {
+	return &Combined{validators: validators}
+}
+
+func (c *Combined) Validate(i interface{}) error {
+	return c.validate(i, (*Validator).ValidateAll)
+}
+
+func (c *Combined) ValidateAll(i interface{}) error {
+	return c.validate(i, (*Validator).ValidateAll)
+}
+
+func (c *Combined) ValidatePaths(i interface{}, paths ...string) error {
+	return c.validate(i, func(validator *Validator, i interface{}) error {
+		scoped := *validator
+		scoped.paths = newPathFilter(paths)
+		return scoped.ValidateAll(i)
+	})
+}
+
 func (v *Validator) WithCustomErrors(errors CustomErrors) *Validator {
 	for field, validationErrors := range errors {
 		if _, exists := v.customErrors[field]; !exists {
@@ -45,162 +166,3147 @@ func (v *Validator) WithCustomErrors(errors CustomErrors) *Validator {
 	return v
 }
 
+func (v *Validator) WithRuleSeverity(rule Rule, severity Severity) *Validator {
+	v.severities[rule] = severity
+	return v
+}
+
+func (v *Validator) CaseInsensitiveFieldKeys() *Validator {
+	v.caseInsensitiveKeys = true
+	return v
+}
+
+var reservedRuleNames = map[string]bool{
+	"-":         true,
+	"dive":      true,
+	"endkeys":   true,
+	"keys":      true,
+	"omitempty": true,
+	"required":  true,
+}
+
+func (v *Validator) RegisterRule(name string, fn RuleFunc) *Validator {
+	if reservedRuleNames[name] {
+		panic(fmt.Sprintf("validator: RegisterRule cannot register %q, it is a reserved rule modifier", name))
+	}
+	v.rules[name] = fn
+	return v
+}
+
+func (v *Validator) RegisterNormalizer(name string, fn func(string) string) *Validator {
+	v.normalizers[name] = fn
+	return v
+}
+
+func (v *Validator) RegisterAccessor(sample interface{}, accessor func(interface{}) map[string]interface{}) *Validator {
+	typ := reflect.TypeOf(sample)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	v.accessors[typ] = accessor
+	return v
+}
+
+func (v *Validator) RegisterEnum(sample interface{}, values []int64) *Validator {
+	set := make(map[int64]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	v.enums[reflect.TypeOf(sample)] = set
+	return v
+}
+
+func (v *Validator) SetClock(now func() time.Time) *Validator {
+	v.now = now
+	return v
+}
+
+func (v *Validator) RegisterHolidays(dates ...time.Time) *Validator {
+	for _, date := range dates {
+		v.holidays[date.Format(time.DateOnly)] = struct{}{}
+	}
+	return v
+}
+
+func (v *Validator) RegisterRegex(name string, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	v.patterns[name] = re
+	return nil
+}
+
+func (v *Validator) LoadAllowlist(name string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	words := make(map[string]struct{})
+	for _, line := range strings.Split(string(data), "\n") {
+		if word := strings.TrimSpace(line); word != "" {
+			words[word] = struct{}{}
+		}
+	}
+	v.wordSets[name] = words
+	return nil
+}
+
+func (v *Validator) RegisterValueSet(name string, values []string) *Validator {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	v.wordSets[name] = set
+	return v
+}
+
+func (v *Validator) RegisterSwitch(field string, cases map[string]string) *Validator {
+	v.switches[field] = cases
+	return v
+}
+
+func (v *Validator) RegisterMapThreshold(field string, threshold float64, maxCount int) *Validator {
+	v.thresholds[field] = mapThreshold{threshold: threshold, maxCount: maxCount}
+	return v
+}
+
+func (v *Validator) RegisterKeySet(name string, m interface{}) *Validator {
+	val := reflect.ValueOf(m)
+	if val.Kind() != reflect.Map {
+		panic(fmt.Sprintf("validator: RegisterKeySet expects a map, got %s", val.Kind()))
+	}
+
+	keys := make(map[string]struct{}, val.Len())
+	for _, key := range val.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = struct{}{}
+	}
+	v.keySets[name] = keys
+	return v
+}
+
+func (v *Validator) WithStrictTags(strict bool) *Validator {
+	v.strictTags = strict
+	return v
+}
+
+func (v *Validator) CombineFieldErrors() *Validator {
+	v.combineFieldErrors = true
+	return v
+}
+
+func (v *Validator) DistinguishNilCollections() *Validator {
+	v.nilOnlyCollections = true
+	return v
+}
+
+func (v *Validator) WithTagName(name string) *Validator {
+	v.tagName = name
+	return v
+}
+
+func (v *Validator) WithFieldNameFunc(fn func(reflect.StructField) string) *Validator {
+	v.fieldNameFunc = fn
+	return v
+}
+
+func (v *Validator) UseJSONFieldNames() *Validator {
+	return v.WithFieldNameFunc(JSONFieldName)
+}
+
+func (v *Validator) customError(field string, rule Rule) (ErrorMsg, bool) {
+	if message, ok := v.customErrors[Field(field)][rule]; ok {
+		return message, true
+	}
+
+	if v.caseInsensitiveKeys {
+		for key, rules := range v.customErrors {
+			if strings.EqualFold(string(key), field) {
+				if message, ok := rules[rule]; ok {
+					return message, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+const wildcardField = "*"
+
+func (v *Validator) ruleCustomError(field string, rule Rule) (ErrorMsg, bool) {
+	if message, ok := v.customError(field, rule); ok {
+		return message, true
+	}
+	if alias, aliased := ruleAliases[rule]; aliased {
+		return v.customError(field, alias)
+	}
+	return "", false
+}
+
+var ruleAliases = map[Rule]Rule{
+	"uuid3": "uuid",
+	"uuid4": "uuid",
+	"uuid5": "uuid",
+}
+
 func (v *Validator) Validate(i interface{}) error {
+	val, err := structValue(i)
+	if err != nil {
+		return err
+	}
+
+	return v.validateStruct(val, "", nil)
+}
+
+func (v *Validator) ValidateAll(i interface{}) error {
+	val, err := structValue(i)
+	if err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	if err := v.validateStruct(val, "", &errs); err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (v *Validator) ValidatePaths(i interface{}, paths ...string) error {
+	val, err := structValue(i)
+	if err != nil {
+		return err
+	}
+
+	scoped := *v
+	scoped.paths = newPathFilter(paths)
+	return scoped.validateStruct(val, "", nil)
+}
+
+func structValue(i interface{}) (reflect.Value, error) {
 	val := reflect.ValueOf(i)
-	typ := reflect.TypeOf(i)
+	if !val.IsValid() {
+		return reflect.Value{}, fmt.Errorf("validate: expected struct, got nil")
+	}
 
-	if val.Kind() == reflect.Ptr {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}, fmt.Errorf("validate: expected struct, got nil %s", val.Type())
+		}
 		val = val.Elem()
-		typ = typ.Elem()
 	}
 
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		fieldType := typ.Field(i)
-		tag := fieldType.Tag
+	if val.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("validate: expected struct, got %s", val.Kind())
+	}
+	return val, nil
+}
 
-		if fieldType.PkgPath != "" {
+func (c *Combined) validate(i interface{}, validate func(*Validator, interface{}) error) error {
+	var errs ValidationErrors
+	seen := make(map[string]bool)
+
+	for _, validator := range c.validators {
+		err := validate(validator, i)
+		if err == nil {
 			continue
 		}
 
-		validationTag := tag.Get("validate")
-		if validationTag != "" {
-			if err := v.validateField(field, fieldType.Name, validationTag); err != nil {
-				if customError, ok := v.customErrors[Field(fieldType.Name)]["required"]; ok {
-					if err.Error() == "field is required" {
-						return &ValidationError{
-							Field:   fieldType.Name,
-							Message: ErrorMsg(customError),
-						}
-					}
-				}
-
-				if customError, ok := v.customErrors[Field(fieldType.Name)]["max"]; ok {
-					if err.Error() == fmt.Sprintf("value exceeds maximum of %d", getValidationMaxValue(validationTag)) {
-						return &ValidationError{
-							Field:   fieldType.Name,
-							Message: customError,
-						}
-					}
-				}
+		found := ValidationErrors{err}
+		if multi, ok := err.(ValidationErrors); ok {
+			found = multi
+		}
 
-				return err
+		for _, e := range found {
+			key := e.Error()
+			if validationErr, ok := e.(*ValidationError); ok {
+				key = validationErr.Field + "\x00" + string(validationErr.Rule)
+			}
+			if !seen[key] {
+				seen[key] = true
+				errs = append(errs, e)
 			}
 		}
 	}
 
-	return nil
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
 }
 
-func (v *Validator) validateField(field reflect.Value, fieldName string, validationTag string) error {
-	if field.Kind() == reflect.Ptr {
-		if field.IsNil() {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: "field is required",
+func (v *Validator) validateStruct(val reflect.Value, path string, errs *ValidationErrors) error {
+	typ := val.Type()
+
+	var accessed map[string]interface{}
+	if accessor, ok := v.accessors[typ]; ok && val.CanInterface() {
+		accessed = accessor(val.Interface())
+	}
+
+	for _, meta := range cachedFields(typ, v.tagName) {
+		if meta.skip {
+			continue
+		}
+
+		if err := v.checkTag(meta, typ, path); err != nil {
+			if errs == nil {
+				return err
 			}
+			*errs = append(*errs, err)
+			continue
 		}
-		field = field.Elem()
-	}
 
-	rules := parseValidationTag(validationTag)
+		field := val.Field(meta.index)
 
-	for _, rule := range rules {
-		if rule == "required" && isZeroValue(field) {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: "field is required",
+		if meta.name == "_" {
+			if !v.paths.selects(strings.TrimSuffix(path, ".")) {
+				continue
+			}
+			if err := validateStructRules(val, structName(typ, path), meta.rules); err != nil {
+				if errs == nil {
+					return err
+				}
+				*errs = append(*errs, err)
 			}
+			continue
 		}
 
-		if err := validateMaxMin(field, rule); err != nil {
-			return err
+		if !meta.exported {
+			value, ok := accessed[meta.name]
+			if !ok {
+				continue
+			}
+			field = reflect.Zero(meta.typ)
+			if value != nil {
+				field = reflect.ValueOf(value)
+			}
 		}
 
-		if err := validateLen(field, rule); err != nil {
-			return err
+		fieldName := path + v.displayName(meta)
+
+		selected, ancestor := v.paths.match(fieldName)
+		if !selected && !ancestor && !meta.anonymous {
+			continue
 		}
 
-		if err := validateEmail(field, rule); err != nil {
-			return err
+		if selected && len(meta.rules) > 0 {
+			rules, err := v.switchRules(val, field, meta, fieldName)
+			if err == nil {
+				err = v.validateRules(val, field, fieldName, rules, errs != nil)
+			}
+			if err != nil {
+				if errs == nil {
+					return v.resolveError(err, fieldName)
+				}
+				for _, e := range flattenErrors(err) {
+					*errs = append(*errs, v.resolveError(e, fieldName))
+				}
+				continue
+			}
+		}
+
+		if threshold, ok := v.thresholds[meta.name]; ok && selected {
+			if err := threshold.check(field); err != nil {
+				err = v.resolveError(v.ruleError(err, fieldName, "mapthreshold"), fieldName)
+				if errs == nil {
+					return err
+				}
+				*errs = append(*errs, err)
+				continue
+			}
+		}
+
+		if nested, ok := nestedStruct(field); ok {
+			nestedPath := fieldName + "."
+			if meta.anonymous {
+				nestedPath = path
+			}
+			if err := v.validateStruct(nested, nestedPath, errs); err != nil {
+				return err
+			}
+		} else if !selected && (field.Kind() == reflect.Slice || field.Kind() == reflect.Array) {
+			for i := 0; i < field.Len(); i++ {
+				if nested, ok := nestedStruct(field.Index(i)); ok {
+					if err := v.validateStruct(nested, fmt.Sprintf("%s[%d].", fieldName, i), errs); err != nil {
+						return err
+					}
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
-func parseValidationTag(validationTag string) []string {
-	return strings.Split(validationTag, ",")
+type pathFilter struct {
+	selected  []*regexp.Regexp
+	ancestors []*regexp.Regexp
 }
 
-func validateMaxMin(field reflect.Value, rule string) error {
-	if strings.HasPrefix(rule, "max=") {
-		max, err := strconv.Atoi(rule[len("max="):])
-		if err == nil && field.Kind() == reflect.Int && field.Int() > int64(max) {
-			return fmt.Errorf("value exceeds maximum of %d", max)
-		} else if field.Kind() == reflect.String && len(field.String()) > max {
-			return fmt.Errorf("length exceeds maximum of %d", max)
+func newPathFilter(paths []string) *pathFilter {
+	filter := &pathFilter{}
+	for _, path := range paths {
+		filter.selected = append(filter.selected, regexp.MustCompile("^"+pathPattern(path)+`(?:[.\[]|$)`))
+		for i := 1; i < len(path); i++ {
+			if path[i] == '.' || path[i] == '[' {
+				filter.ancestors = append(filter.ancestors, regexp.MustCompile("^"+pathPattern(path[:i])+"$"))
+			}
 		}
 	}
+	return filter
+}
 
-	if strings.HasPrefix(rule, "min=") {
-		min, err := strconv.Atoi(rule[len("min="):])
-		if err == nil && field.Kind() == reflect.Int && field.Int() < int64(min) {
-			return fmt.Errorf("value is below minimum of %d", min)
-		} else if field.Kind() == reflect.String && len(field.String()) < min {
-			return fmt.Errorf("length is below minimum of %d", min)
+func pathPattern(path string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(path), `\[\*\]`, `\[[^\]]*\]`)
+}
+
+func (f *pathFilter) match(name string) (selected bool, ancestor bool) {
+	if f == nil {
+		return true, false
+	}
+	for _, re := range f.selected {
+		if re.MatchString(name) {
+			return true, false
+		}
+	}
+	for _, re := range f.ancestors {
+		if re.MatchString(name) {
+			return false, true
 		}
 	}
+	return false, false
+}
 
-	return nil
+func (f *pathFilter) selects(name string) bool {
+	if f == nil {
+		return true
+	}
+	selected, _ := f.match(name)
+	return name != "" && selected
 }
 
-func validateLen(field reflect.Value, rule string) error {
-	if strings.HasPrefix(rule, "len=") {
-		expectedLen, err := strconv.Atoi(rule[len("len="):])
-		if err == nil && field.Kind() == reflect.String && len(field.String()) != expectedLen {
-			return fmt.Errorf("length must be exactly %d", expectedLen)
+type fieldMeta struct {
+	index        int
+	name         string
+	typ          reflect.Type
+	exported     bool
+	rules        []string
+	skip         bool
+	anonymous    bool
+	structField  reflect.StructField
+	paramErr     error
+	unknownRules []string
+}
+
+type fieldCacheKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+var fieldCache sync.Map
+
+func cachedFields(typ reflect.Type, tagName string) []fieldMeta {
+	key := fieldCacheKey{typ: typ, tagName: tagName}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.([]fieldMeta)
+	}
+
+	fields := make([]fieldMeta, typ.NumField())
+	for i := range fields {
+		fieldType := typ.Field(i)
+		fields[i] = fieldMeta{
+			index:       i,
+			name:        fieldType.Name,
+			typ:         fieldType.Type,
+			exported:    fieldType.PkgPath == "",
+			anonymous:   fieldType.Anonymous,
+			structField: fieldType,
+		}
+		switch validationTag := fieldType.Tag.Get(tagName); validationTag {
+		case "":
+		case "-":
+			fields[i].skip = true
+		default:
+			fields[i].rules = parseValidationTag(validationTag)
+			if fieldType.Name == "_" {
+				fields[i].paramErr = checkStructRuleParams(typ, fields[i].rules)
+				fields[i].unknownRules = unknownRuleNames(fields[i].rules, isStructRule)
+			} else {
+				fields[i].paramErr = checkRuleParams(typ, fieldType.Type, fields[i].rules)
+				fields[i].unknownRules = unknownRuleNames(fields[i].rules, isBuiltinRule)
+			}
 		}
 	}
 
-	return nil
+	cached, _ := fieldCache.LoadOrStore(key, fields)
+	return cached.([]fieldMeta)
 }
 
-func validateEmail(field reflect.Value, rule string) error {
-	if rule == "email" && field.Kind() == reflect.String {
-		email := field.String()
-		if !isValidEmail(email) {
-			return fmt.Errorf("invalid email format")
-		}
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
 	}
-	return nil
+	return name
 }
 
-func isZeroValue(field reflect.Value) bool {
-	if field.Kind() == reflect.Ptr {
-		if field.IsNil() {
-			return true
-		}
-		field = field.Elem()
+func (v *Validator) displayName(meta fieldMeta) string {
+	if v.fieldNameFunc == nil {
+		return meta.name
+	}
+	if name := v.fieldNameFunc(meta.structField); name != "" {
+		return name
 	}
+	return meta.name
+}
 
-	return (field.Kind() == reflect.String && field.String() == "") ||
-		(field.Kind() == reflect.Int && field.Int() == 0) ||
-		(field.Kind() == reflect.Slice && field.Len() == 0)
+func (v *Validator) resolveError(err error, fieldName string) error {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return err
+	}
+
+	customError, ok := v.ruleCustomError(fieldName, validationErr.Rule)
+	if !ok {
+		customError, ok = v.ruleCustomError(wildcardField, validationErr.Rule)
+	}
+	if !ok {
+		return validationErr
+	}
+
+	message := strings.NewReplacer(
+		"{field}", validationErr.Field,
+		"{param}", validationErr.Param,
+	).Replace(string(customError))
+
+	return &ValidationError{
+		Field:    validationErr.Field,
+		Message:  ErrorMsg(message),
+		Rule:     validationErr.Rule,
+		Param:    validationErr.Param,
+		Severity: validationErr.Severity,
+	}
 }
 
-func isValidEmail(email string) bool {
-	re := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	return re.MatchString(email)
+func structName(typ reflect.Type, path string) string {
+	if path == "" {
+		return typ.Name()
+	}
+	return strings.TrimSuffix(path, ".")
 }
 
-func getValidationMaxValue(validationTag string) int {
-	if strings.HasPrefix(validationTag, "max=") {
-		maxStr := validationTag[len("max="):]
-		max, err := strconv.Atoi(maxStr)
-		if err == nil {
-			return max
+var timeType = reflect.TypeOf(time.Time{})
+
+func nestedStruct(field reflect.Value) (reflect.Value, bool) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return reflect.Value{}, false
 		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.Struct || field.Type() == timeType {
+		return reflect.Value{}, false
 	}
-	return 0
+	return field, true
+}
+
+func (v *Validator) ValidateAndFix(i interface{}) (fixed []string, err error) {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validate: ValidateAndFix requires a non-nil pointer to a struct")
+	}
+
+	fixed = clampStruct(val.Elem(), "", v.tagName)
+	return fixed, v.Validate(i)
+}
+
+func clampStruct(val reflect.Value, path string, tagName string) []string {
+	var fixed []string
+
+	for _, meta := range cachedFields(val.Type(), tagName) {
+		field := val.Field(meta.index)
+
+		if !meta.exported || meta.skip {
+			continue
+		}
+
+		fieldName := path + meta.name
+
+		if len(meta.rules) > 0 {
+			target := field
+			if target.Kind() == reflect.Ptr && !target.IsNil() {
+				target = target.Elem()
+			}
+			if clampField(target, meta.rules) {
+				fixed = append(fixed, fieldName)
+			}
+		}
+
+		if nested, ok := nestedStruct(field); ok {
+			nestedPath := fieldName + "."
+			if meta.anonymous {
+				nestedPath = path
+			}
+			fixed = append(fixed, clampStruct(nested, nestedPath, tagName)...)
+		}
+	}
+
+	return fixed
+}
+
+func clampField(field reflect.Value, rules []string) bool {
+	if !field.CanSet() || omitEmpty(field, rules) {
+		return false
+	}
+
+	clamped := false
+	for _, rule := range rules {
+		if rule == "dive" {
+			break
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+		if name != "min" && name != "max" {
+			continue
+		}
+
+		switch {
+		case isInt(field):
+			bound, err := strconv.ParseInt(param, 10, 64)
+			if err == nil && ((name == "max" && field.Int() > bound) || (name == "min" && field.Int() < bound)) {
+				field.SetInt(bound)
+				clamped = true
+			}
+		case isUint(field):
+			bound, err := strconv.ParseUint(param, 10, 64)
+			if err == nil && ((name == "max" && field.Uint() > bound) || (name == "min" && field.Uint() < bound)) {
+				field.SetUint(bound)
+				clamped = true
+			}
+		case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+			bound, err := parseFloatParam(field, param)
+			if err == nil && ((name == "max" && field.Float() > bound) || (name == "min" && field.Float() < bound)) {
+				field.SetFloat(bound)
+				clamped = true
+			}
+		}
+	}
+
+	return clamped
+}
+
+var structValidators = map[string]func(parent reflect.Value, param string) error{
+	"maxspan": validateMaxSpan,
+}
+
+func validateStructRules(parent reflect.Value, structName string, rules []string) error {
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if fn, ok := structValidators[name]; ok {
+			if err := fn(parent, param); err != nil {
+				return &ValidationError{
+					Field:   structName,
+					Message: ErrorMsg(err.Error()),
+					Rule:    Rule(name),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateMaxSpan(parent reflect.Value, param string) error {
+	args := strings.Fields(param)
+	if len(args) != 3 {
+		return nil
+	}
+
+	maxSpan, err := time.ParseDuration(args[2])
+	if err != nil {
+		return nil
+	}
+
+	start, ok := timeField(parent, args[0])
+	if !ok {
+		return nil
+	}
+	end, ok := timeField(parent, args[1])
+	if !ok {
+		return nil
+	}
+
+	if start.IsZero() || end.IsZero() {
+		return nil
+	}
+
+	span := end.Sub(start)
+	if span < 0 {
+		span = -span
+	}
+	if span > maxSpan {
+		return fmt.Errorf("%s and %s must be within %s", args[0], args[1], args[2])
+	}
+	return nil
+}
+
+func timeField(parent reflect.Value, name string) (time.Time, bool) {
+	field := parent.FieldByName(name)
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return time.Time{}, false
+		}
+		field = field.Elem()
+	}
+	if !field.IsValid() || !field.CanInterface() {
+		return time.Time{}, false
+	}
+
+	t, ok := field.Interface().(time.Time)
+	return t, ok
+}
+
+func (v *Validator) ValidateUniqueComposite(i interface{}, keyFields []string, seen map[string]bool) error {
+	val, err := structValue(i)
+	if err != nil {
+		return err
+	}
+
+	parts := make([]string, 0, len(keyFields))
+	for _, name := range keyFields {
+		field := val.FieldByName(name)
+		if !field.IsValid() {
+			return fmt.Errorf("field '%s' not found", name)
+		}
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			field = field.Elem()
+		}
+		parts = append(parts, fmt.Sprint(field.Interface()))
+	}
+
+	key := strings.Join(parts, "\x00")
+	if seen[key] {
+		return &ValidationError{
+			Field:   strings.Join(keyFields, ","),
+			Message: ErrorMsg(fmt.Sprintf("duplicate composite key (%s)", strings.Join(parts, ", "))),
+		}
+	}
+	seen[key] = true
+
+	return nil
+}
+
+func (v *Validator) ValidateBudget(items interface{}, field string, budget float64) error {
+	values, err := batchValues(items, field, "ValidateBudget")
+	if err != nil {
+		return err
+	}
+
+	total := 0.0
+	for i, amount := range values {
+		total += amount
+		if total > budget {
+			return &ValidationError{
+				Field:   fmt.Sprintf("[%d].%s", i, field),
+				Message: ErrorMsg(fmt.Sprintf("running total %s exceeds budget of %s at index %d", formatFloat(total), formatFloat(budget), i)),
+				Rule:    "budget",
+				Param:   formatFloat(budget),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateOutliers(items interface{}, field string, maxStdDev float64) []error {
+	values, err := batchValues(items, field, "ValidateOutliers")
+	if err != nil {
+		return []error{err}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(values)))
+	if stdDev == 0 {
+		return nil
+	}
+
+	var errs []error
+	for i, value := range values {
+		deviations := math.Abs(value-mean) / stdDev
+		if deviations > maxStdDev {
+			errs = append(errs, &ValidationError{
+				Field:   fmt.Sprintf("[%d].%s", i, field),
+				Message: ErrorMsg(fmt.Sprintf("value %s is %.2f standard deviations from the mean of %s", formatFloat(value), deviations, formatFloat(mean))),
+				Rule:    "outlier",
+				Param:   formatFloat(maxStdDev),
+			})
+		}
+	}
+	return errs
+}
+
+func batchValues(items interface{}, field string, caller string) ([]float64, error) {
+	val := reflect.ValueOf(items)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, fmt.Errorf("validate: %s expects a slice, got %s", caller, val.Kind())
+	}
+
+	values := make([]float64, val.Len())
+	for i := range values {
+		item, ok := nestedStruct(val.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("validate: %s expects a slice of structs, got %s", caller, val.Index(i).Kind())
+		}
+
+		value := item.FieldByName(field)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("field '%s' not found", field)
+		}
+		number, ok := numberValue(value)
+		if !ok {
+			return nil, fmt.Errorf("field '%s' is not numeric", field)
+		}
+		values[i] = number
+	}
+	return values, nil
+}
+
+func (v *Validator) validateRules(parent reflect.Value, field reflect.Value, fieldName string, rules []string, all bool) error {
+	if omitEmpty(field, rules) {
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return v.validateNilPointer(parent, field, fieldName, rules)
+		}
+		field = field.Elem()
+	}
+
+	var failures []*ValidationError
+	for i, rule := range rules {
+		if rule == "dive" {
+			if len(failures) > 0 {
+				break
+			}
+			return v.validateDive(parent, field, fieldName, rules[i+1:], all)
+		}
+
+		if err := v.checkRule(parent, field, fieldName, rule); err != nil {
+			err = v.ruleError(err, fieldName, rule)
+			validationErr, ok := err.(*ValidationError)
+			if !v.combineFieldErrors || !ok {
+				return err
+			}
+			failures = append(failures, v.resolveError(validationErr, fieldName).(*ValidationError))
+		}
+	}
+
+	return combineFailures(failures)
+}
+
+func omitEmpty(field reflect.Value, rules []string) bool {
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		rules = rules[:i]
+	}
+	return slices.Contains(rules, "omitempty") && !slices.Contains(rules, "required") && isEmptyValue(field)
+}
+
+func isEmptyValue(field reflect.Value) bool {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return true
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() == reflect.Slice || field.Kind() == reflect.Map {
+		return field.Len() == 0
+	}
+	return field.IsZero()
+}
+
+func (v *Validator) validateNilPointer(parent reflect.Value, field reflect.Value, fieldName string, rules []string) error {
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		rules = rules[:i]
+	}
+
+	if !slices.Contains(rules, "required") && slices.ContainsFunc(rules, isConditionalRequired) {
+		for _, rule := range rules {
+			if !isConditionalRequired(rule) {
+				continue
+			}
+			if err := v.validateRequiredIf(parent, field, rule); err != nil {
+				return v.ruleError(err, fieldName, rule)
+			}
+		}
+		return nil
+	}
+
+	return v.ruleError(&ValidationError{
+		Field:   fieldName,
+		Message: "field is required",
+	}, fieldName, "required")
+}
+
+var builtinRules = map[string]bool{
+	"abspath": true, "after": true, "base32": true, "base32hex": true, "before": true,
+	"businessday": true, "canonical": true, "datetime": true, "datetime_any": true, "digits": true,
+	"digits_between": true, "email": true, "enum": true, "envname": true, "eq": true,
+	"filepath": true, "fits": true, "flags": true, "goidentifier": true, "hex": true,
+	"increment": true, "inkeysof": true, "jsonpointer": true, "len": true, "lenmatchescount": true,
+	"luhn": true, "max": true, "maxwidth": true, "min": true, "ne": true,
+	"not_inset": true, "oneof": true, "postcode": true, "powerof2": true, "rect": true,
+	"regex": true, "regex_syntax": true, "regexname": true, "required_if": true, "required_unless": true, "required_without": true,
+	"semverrange": true, "sha256of": true, "slug": true, "subset": true, "subsetof": true,
+	"switchon": true, "today": true, "unique": true, "uniquedeep": true, "url": true,
+	"urlencoded": true, "weekday": true,
+}
+
+func isBuiltinRule(name string) bool {
+	if builtinRules[name] || reservedRuleNames[name] {
+		return true
+	}
+	if _, ok := comparisonRules[name]; ok {
+		return true
+	}
+	if _, ok := fieldComparisonRules[name]; ok {
+		return true
+	}
+	if _, ok := substringRules[name]; ok {
+		return true
+	}
+	if _, ok := characterClasses[name]; ok {
+		return true
+	}
+	if _, ok := parseIntRules[name]; ok {
+		return true
+	}
+	_, isUUID := uuidVersions[name]
+	return isUUID || base32Encodings[name] != nil
+}
+
+func unknownRuleNames(rules []string, known func(name string) bool) []string {
+	var unknown []string
+	for _, rule := range rules {
+		name, _, _ := strings.Cut(rule, "=")
+		if !known(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+func isStructRule(name string) bool {
+	_, ok := structValidators[name]
+	return ok
+}
+
+func (v *Validator) checkRuleNames(unknown []string, fieldName string) error {
+	var unregistered []string
+	for _, name := range unknown {
+		if _, ok := v.rules[name]; !ok {
+			unregistered = append(unregistered, name)
+		}
+	}
+	return unknownRulesError(unregistered, fieldName)
+}
+
+func unknownRulesError(unknown []string, fieldName string) error {
+	quoted := make([]string, len(unknown))
+	for i, name := range unknown {
+		quoted[i] = strconv.Quote(name)
+	}
+
+	switch len(quoted) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: unknown validation rule %s on field %s", ErrInvalidRule, quoted[0], fieldName)
+	}
+	return fmt.Errorf("%w: unknown validation rules %s on field %s", ErrInvalidRule, strings.Join(quoted, ", "), fieldName)
+}
+
+func (v *Validator) checkTag(meta fieldMeta, typ reflect.Type, path string) error {
+	if !v.strictTags {
+		return nil
+	}
+	if meta.paramErr != nil {
+		return meta.paramErr
+	}
+
+	if meta.name == "_" {
+		return unknownRulesError(meta.unknownRules, structName(typ, path))
+	}
+	return v.checkRuleNames(meta.unknownRules, path+v.displayName(meta))
+}
+
+func checkRuleParams(parent reflect.Type, typ reflect.Type, rules []string) error {
+	for i := 1; i < len(rules); i++ {
+		if strings.Contains(rules[i-1], "=") && isStrayToken(rules[i]) {
+			return fmt.Errorf("%w: %q is not a rule; escape the comma in %q as \\, or quote the parameter", ErrInvalidRule, rules[i], rules[i-1]+","+rules[i])
+		}
+	}
+
+	typ = derefType(typ)
+	container := typ
+	for _, rule := range rules {
+		switch rule {
+		case "dive":
+			container = typ
+			if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+				typ = derefType(typ.Elem())
+			}
+			continue
+		case "keys":
+			if container.Kind() == reflect.Map {
+				typ = derefType(container.Key())
+			}
+			continue
+		case "endkeys":
+			if container.Kind() == reflect.Map {
+				typ = derefType(container.Elem())
+			}
+			continue
+		}
+
+		if err := checkRuleParam(parent, typ, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isStrayToken(rule string) bool {
+	name, _, _ := strings.Cut(rule, "=")
+	if name == "" || name == "-" || (name[0] >= '0' && name[0] <= '9') {
+		return true
+	}
+	return strings.IndexFunc(name, func(r rune) bool {
+		return r != '_' && r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) >= 0
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func checkRuleParam(parent reflect.Type, typ reflect.Type, rule string) error {
+	name, param, hasParam := strings.Cut(rule, "=")
+
+	var err error
+	switch name {
+	case "min", "max":
+		err = checkNumberParam(typ, param)
+	case "len", "maxwidth", "digits":
+		_, err = strconv.Atoi(param)
+	case "hex":
+		if hasParam {
+			_, err = strconv.Atoi(param)
+		}
+	case "digits_between":
+		minParam, maxParam, ok := strings.Cut(strings.Trim(param, "'"), ",")
+		if !ok {
+			return fmt.Errorf("%w: malformed parameter in %q, expected MIN,MAX", ErrInvalidRule, rule)
+		}
+		if _, err = strconv.Atoi(strings.TrimSpace(minParam)); err == nil {
+			_, err = strconv.Atoi(strings.TrimSpace(maxParam))
+		}
+	case "gt", "gte", "lt", "lte":
+		if !isNumberKind(typ.Kind()) {
+			return fmt.Errorf("%w: rule %s not applicable to %s, use min/max for lengths", ErrInvalidRule, name, typ.Kind())
+		}
+		err = checkNumberParam(typ, param)
+	case "eq", "ne":
+		switch kind := typ.Kind(); {
+		case kind == reflect.Bool:
+			_, err = strconv.ParseBool(param)
+		case isNumberKind(kind):
+			err = checkNumberParam(typ, param)
+		case kind != reflect.String:
+			return fmt.Errorf("%w: rule %s not applicable to %s", ErrInvalidRule, name, kind)
+		}
+	case "increment":
+		var step float64
+		if step, err = strconv.ParseFloat(param, 64); err == nil && step <= 0 {
+			err = errors.New("step must be positive")
+		}
+	case "flags":
+		for _, flag := range strings.Fields(param) {
+			if _, err = strconv.ParseUint(flag, 0, 64); err != nil {
+				break
+			}
+		}
+	case "fits":
+		if _, ok := integerTypeBounds[param]; !ok {
+			err = errors.New("unknown integer type")
+		}
+	case "weekday":
+		if hasParam {
+			for _, day := range strings.Fields(param) {
+				if _, ok := parseWeekday(day); !ok {
+					err = errors.New("unknown weekday")
+				}
+			}
+		}
+	case "before", "after":
+		if param != "now" {
+			_, err = time.Parse(time.RFC3339, param)
+		}
+	case "regex":
+		_, err = compileInlineRegex(unquoteParam(param))
+	case "oneof", "subset", "datetime", "datetime_any", "today", "regexname", "inkeysof", "not_inset", "subsetof", "canonical":
+		if param == "" {
+			err = errors.New("missing parameter")
+		}
+	case "eqfield", "nefield", "gtfield", "ltfield", "lenmatchescount", "sha256of", "postcode", "switchon":
+		return checkFieldParams(parent, rule, []string{param})
+	case "required_without":
+		fields := strings.Fields(param)
+		if len(fields) == 0 {
+			return fmt.Errorf("%w: malformed parameter in %q", ErrInvalidRule, rule)
+		}
+		return checkFieldParams(parent, rule, fields)
+	case "required_if", "required_unless":
+		params := splitOneOfParams(param)
+		if len(params) == 0 || len(params)%2 != 0 {
+			return fmt.Errorf("%w: malformed parameter in %q, expected field and value pairs", ErrInvalidRule, rule)
+		}
+		for i := 0; i < len(params); i += 2 {
+			if err := checkFieldParams(parent, rule, params[i:i+1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: malformed parameter in %q", ErrInvalidRule, rule)
+	}
+	return nil
+}
+
+func checkStructRuleParams(typ reflect.Type, rules []string) error {
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if name != "maxspan" {
+			continue
+		}
+
+		args := strings.Fields(param)
+		if len(args) != 3 {
+			return fmt.Errorf("%w: malformed parameter in %q, expected START END DURATION", ErrInvalidRule, rule)
+		}
+		if _, err := time.ParseDuration(args[2]); err != nil {
+			return fmt.Errorf("%w: malformed parameter in %q", ErrInvalidRule, rule)
+		}
+		if err := checkFieldParams(typ, rule, args[:2]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkFieldParams(parent reflect.Type, rule string, names []string) error {
+	for _, name := range names {
+		if _, ok := parent.FieldByName(name); !ok {
+			return fmt.Errorf("%w: %q references unknown field %q", ErrInvalidRule, rule, name)
+		}
+	}
+	return nil
+}
+
+func checkNumberParam(typ reflect.Type, param string) error {
+	var err error
+	switch typ.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, err = strconv.ParseUint(param, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(param, typ.Bits())
+	case reflect.Interface:
+		_, err = strconv.ParseFloat(param, 64)
+	default:
+		_, err = strconv.ParseInt(param, 10, 64)
+	}
+	return err
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func combineFailures(failures []*ValidationError) error {
+	switch len(failures) {
+	case 0:
+		return nil
+	case 1:
+		return failures[0]
+	}
+
+	messages := make([]string, len(failures))
+	rules := make([]string, len(failures))
+	for i, failure := range failures {
+		messages[i] = string(failure.Message)
+		rules[i] = string(failure.Rule)
+	}
+
+	return &ValidationError{
+		Field:    failures[0].Field,
+		Message:  ErrorMsg(strings.Join(messages, "; ")),
+		Rule:     Rule(strings.Join(rules, ",")),
+		Severity: failures[0].Severity,
+	}
+}
+
+func (v *Validator) ruleError(err error, fieldName string, rule string) error {
+	if errors.Is(err, ErrInvalidRule) {
+		return err
+	}
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		validationErr = &ValidationError{
+			Field:   fieldName,
+			Message: ErrorMsg(err.Error()),
+		}
+	}
+
+	name, param, _ := strings.Cut(rule, "=")
+	if validationErr.Field == "" {
+		validationErr.Field = fieldName
+	}
+	if validationErr.Rule == "" {
+		validationErr.Rule = Rule(name)
+		validationErr.Param = param
+	}
+	if severity, ok := v.severities[validationErr.Rule]; ok {
+		validationErr.Severity = severity
+	}
+
+	return validationErr
+}
+
+func (v *Validator) checkRule(parent reflect.Value, field reflect.Value, fieldName string, rule string) error {
+	if rule == "required" && v.isMissing(field) {
+		return &ValidationError{
+			Field:   fieldName,
+			Message: "field is required",
+		}
+	}
+
+	if err := v.validateRequiredIf(parent, field, rule); err != nil {
+		return err
+	}
+
+	if err := validateMaxMin(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateComparison(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateEq(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateNe(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateLen(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateEmail(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateUUID(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateOneOf(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateURL(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateURLEncoded(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateRegex(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateFilePath(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateJSONPointer(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateEnvName(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateSlug(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateCharacterClass(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateSubstring(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateSemverRange(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateGoIdentifier(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateRegexSyntax(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateMaxWidth(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateHex(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateBase32(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateDatetimeAny(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateWeekday(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateBusinessDay(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateToday(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateTime(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateParseInt(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateFits(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateFlags(field, rule); err != nil {
+		return err
+	}
+
+	if err := validatePowerOf2(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateDigits(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateLuhn(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateIncrement(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateInKeysOf(field, fieldName, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateEnum(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateCanonical(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateNotInSet(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateLenMatchesCount(parent, field, rule); err != nil {
+		return err
+	}
+
+	if err := validateFieldComparison(parent, field, rule); err != nil {
+		return err
+	}
+
+	if err := validateSHA256Of(parent, field, rule); err != nil {
+		return err
+	}
+
+	if err := validatePostcode(parent, field, rule); err != nil {
+		return err
+	}
+
+	if err := validateUnique(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateUniqueDeep(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateSubset(field, rule); err != nil {
+		return err
+	}
+
+	if err := validateRect(field, rule); err != nil {
+		return err
+	}
+
+	if err := v.validateSubsetOf(field, fieldName, rule); err != nil {
+		return err
+	}
+
+	name, param, _ := strings.Cut(rule, "=")
+	if fn, ok := v.rules[name]; ok {
+		if err := fn(field, param); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) validateRequiredIf(parent reflect.Value, field reflect.Value, rule string) error {
+	name, param, _ := strings.Cut(rule, "=")
+
+	var (
+		required bool
+		err      error
+	)
+	switch name {
+	case "required_if":
+		required, err = siblingsMatch(parent, name, param)
+	case "required_unless":
+		required, err = siblingsMatch(parent, name, param)
+		required = !required
+	case "required_without":
+		required, err = v.anySiblingMissing(parent, name, param)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	if required && v.isMissing(field) {
+		return fmt.Errorf("field is required")
+	}
+	return nil
+}
+
+func siblingsMatch(parent reflect.Value, name string, param string) (bool, error) {
+	params := splitOneOfParams(param)
+	if len(params) == 0 || len(params)%2 != 0 {
+		return false, fmt.Errorf("%w: %s expects field and value pairs, got %q", ErrInvalidRule, name, param)
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		value, ok := siblingValue(parent, params[i])
+		if !ok {
+			return false, fmt.Errorf("%w: %s field %q not found", ErrInvalidRule, name, params[i])
+		}
+		if value != params[i+1] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (v *Validator) anySiblingMissing(parent reflect.Value, name string, param string) (bool, error) {
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return false, fmt.Errorf("%w: %s expects at least one field", ErrInvalidRule, name)
+	}
+
+	missing := false
+	for _, other := range fields {
+		sibling := parent.FieldByName(other)
+		if !sibling.IsValid() {
+			return false, fmt.Errorf("%w: %s field %q not found", ErrInvalidRule, name, other)
+		}
+		if v.isMissing(sibling) {
+			missing = true
+		}
+	}
+	return missing, nil
+}
+
+func isConditionalRequired(rule string) bool {
+	return strings.HasPrefix(rule, "required_if=") || strings.HasPrefix(rule, "required_unless=") || strings.HasPrefix(rule, "required_without=")
+}
+
+func siblingValue(parent reflect.Value, name string) (string, bool) {
+	field := parent.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return "", false
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", true
+		}
+		field = field.Elem()
+	}
+	return fmt.Sprint(field.Interface()), true
+}
+
+func (v *Validator) validateDive(parent reflect.Value, field reflect.Value, fieldName string, rules []string, all bool) error {
+	if field.Kind() == reflect.Map {
+		return v.validateMapDive(parent, field, fieldName, rules, all)
+	}
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return nil
+	}
+
+	var found ValidationErrors
+	for i := 0; i < field.Len(); i++ {
+		elem := field.Index(i)
+		elemName := fmt.Sprintf("%s[%d]", fieldName, i)
+
+		if err := v.validateElement(parent, elem, elemName, rules, all, &found); err != nil {
+			return err
+		}
+	}
+
+	return diveErrors(found)
+}
+
+func (v *Validator) validateMapDive(parent reflect.Value, field reflect.Value, fieldName string, rules []string, all bool) error {
+	var keyRules []string
+	if len(rules) > 0 && rules[0] == "keys" {
+		end := slices.Index(rules, "endkeys")
+		if end < 0 {
+			return fmt.Errorf("%w: keys without a matching endkeys", ErrInvalidRule)
+		}
+		keyRules, rules = rules[1:end], rules[end+1:]
+	}
+
+	keys := field.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	})
+
+	var found ValidationErrors
+	for _, key := range keys {
+		elemName := fmt.Sprintf("%s[%v]", fieldName, key.Interface())
+
+		if len(keyRules) > 0 {
+			if err := v.validateRules(parent, key, elemName, keyRules, all); err != nil {
+				if !all {
+					return err
+				}
+				found = append(found, flattenErrors(err)...)
+				continue
+			}
+		}
+
+		if err := v.validateElement(parent, field.MapIndex(key), elemName, rules, all, &found); err != nil {
+			return err
+		}
+	}
+
+	return diveErrors(found)
+}
+
+func (v *Validator) validateElement(parent reflect.Value, elem reflect.Value, elemName string, rules []string, all bool, found *ValidationErrors) error {
+	if len(rules) > 0 {
+		if err := v.validateRules(parent, elem, elemName, rules, all); err != nil {
+			if !all {
+				return err
+			}
+			*found = append(*found, flattenErrors(err)...)
+			return nil
+		}
+	}
+
+	if nested, ok := nestedStruct(elem); ok {
+		if !all {
+			return v.validateStruct(nested, elemName+".", nil)
+		}
+		return v.validateStruct(nested, elemName+".", found)
+	}
+	return nil
+}
+
+func flattenErrors(err error) ValidationErrors {
+	if multi, ok := err.(ValidationErrors); ok {
+		return multi
+	}
+	return ValidationErrors{err}
+}
+
+func diveErrors(found ValidationErrors) error {
+	if len(found) == 0 {
+		return nil
+	}
+	return found
+}
+
+func (v *Validator) validateInKeysOf(field reflect.Value, fieldName string, rule string) error {
+	if !strings.HasPrefix(rule, "inkeysof=") {
+		return nil
+	}
+
+	keys, ok := v.keySets[rule[len("inkeysof="):]]
+	if !ok || !field.CanInterface() {
+		return nil
+	}
+
+	if _, ok := keys[fmt.Sprint(field.Interface())]; !ok {
+		return fmt.Errorf("invalid %s", strings.ToLower(baseFieldName(fieldName)))
+	}
+	return nil
+}
+
+func (v *Validator) validateEnum(field reflect.Value, rule string) error {
+	if rule != "enum" {
+		return nil
+	}
+
+	values, ok := v.enums[field.Type()]
+	if !ok {
+		return nil
+	}
+
+	var value int64
+	switch {
+	case isInt(field):
+		value = field.Int()
+	case isUint(field):
+		value = int64(field.Uint())
+	default:
+		return nil
+	}
+
+	if _, ok := values[value]; !ok {
+		return fmt.Errorf("invalid enum value")
+	}
+	return nil
+}
+
+func (v *Validator) validateNotInSet(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "not_inset=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	words, ok := v.wordSets[rule[len("not_inset="):]]
+	if !ok {
+		return nil
+	}
+
+	if _, ok := words[field.String()]; ok {
+		return fmt.Errorf("value is not allowed")
+	}
+	return nil
+}
+
+func (v *Validator) switchRules(parent reflect.Value, field reflect.Value, meta fieldMeta, fieldName string) ([]string, error) {
+	cases, ok := v.switches[meta.name]
+	if !ok {
+		return meta.rules, nil
+	}
+
+	i := slices.IndexFunc(meta.rules, func(rule string) bool { return strings.HasPrefix(rule, "switchon=") })
+	if i < 0 {
+		return meta.rules, nil
+	}
+
+	var caseRules []string
+	discriminator := parent.FieldByName(meta.rules[i][len("switchon="):])
+	if discriminator.IsValid() && discriminator.CanInterface() {
+		if validationTag, ok := cases[fmt.Sprint(discriminator.Interface())]; ok {
+			caseRules = parseValidationTag(validationTag)
+		}
+	}
+
+	if v.strictTags {
+		if err := checkRuleParams(parent.Type(), field.Type(), caseRules); err != nil {
+			return nil, err
+		}
+		if err := v.checkRuleNames(unknownRuleNames(caseRules, isBuiltinRule), fieldName); err != nil {
+			return nil, err
+		}
+	}
+	return slices.Concat(meta.rules[:i], caseRules, meta.rules[i+1:]), nil
+}
+
+func baseFieldName(fieldName string) string {
+	name := fieldName[strings.LastIndex(fieldName, ".")+1:]
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func validateLenMatchesCount(parent reflect.Value, field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "lenmatchescount=") || !isCollection(field) {
+		return nil
+	}
+
+	sibling := parent.FieldByName(rule[len("lenmatchescount="):])
+
+	var setFlags int
+	switch {
+	case isInt(sibling):
+		setFlags = bits.OnesCount64(uint64(sibling.Int()))
+	case isUint(sibling):
+		setFlags = bits.OnesCount64(sibling.Uint())
+	default:
+		return nil
+	}
+
+	if field.Len() != setFlags {
+		return fmt.Errorf("length must equal number of set flags")
+	}
+	return nil
+}
+
+var fieldComparisonRules = map[string]struct {
+	accept  func(result int) bool
+	message string
+}{
+	"eqfield": {func(result int) bool { return result == 0 }, "value must equal field %s"},
+	"nefield": {func(result int) bool { return result != 0 }, "value must not equal field %s"},
+	"gtfield": {func(result int) bool { return result > 0 }, "value must be greater than field %s"},
+	"ltfield": {func(result int) bool { return result < 0 }, "value must be less than field %s"},
+}
+
+func validateFieldComparison(parent reflect.Value, field reflect.Value, rule string) error {
+	name, other, _ := strings.Cut(rule, "=")
+	comparison, ok := fieldComparisonRules[name]
+	if !ok {
+		return nil
+	}
+
+	sibling := parent.FieldByName(other)
+	if !sibling.IsValid() {
+		return fmt.Errorf("%w: %s references unknown field %q", ErrInvalidRule, name, other)
+	}
+	if sibling.Kind() == reflect.Ptr {
+		if sibling.IsNil() {
+			return nil
+		}
+		sibling = sibling.Elem()
+	}
+
+	var result int
+	if name == "eqfield" || name == "nefield" {
+		if field.Type() != sibling.Type() || !field.Type().Comparable() {
+			return fmt.Errorf("%w: %s cannot compare %s with field %s of type %s", ErrInvalidRule, name, field.Type(), other, sibling.Type())
+		}
+		if !field.Equal(sibling) {
+			result = 1
+		}
+	} else {
+		result, ok = compareValues(field, sibling)
+		if !ok {
+			return fmt.Errorf("%w: %s cannot order %s against field %s of type %s", ErrInvalidRule, name, field.Type(), other, sibling.Type())
+		}
+	}
+
+	if !comparison.accept(result) {
+		return fmt.Errorf(comparison.message, other)
+	}
+	return nil
+}
+
+func compareValues(a reflect.Value, b reflect.Value) (int, bool) {
+	switch {
+	case a.Type() == timeType && b.Type() == timeType && a.CanInterface() && b.CanInterface():
+		x, y := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case x.After(y):
+			return 1, true
+		case x.Before(y):
+			return -1, true
+		}
+		return 0, true
+	case isInt(a) && isInt(b):
+		return cmp.Compare(a.Int(), b.Int()), true
+	case isUint(a) && isUint(b):
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	}
+
+	x, ok := numberValue(a)
+	if !ok {
+		return 0, false
+	}
+	y, ok := numberValue(b)
+	if !ok {
+		return 0, false
+	}
+	return cmp.Compare(x, y), true
+}
+
+var postcodeRegexps = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^[0-9]{5}(-?[0-9]{4})?$`),
+	"UK": regexp.MustCompile(`(?i)^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`),
+	"GB": regexp.MustCompile(`(?i)^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`),
+	"CA": regexp.MustCompile(`(?i)^[A-Z][0-9][A-Z] ?[0-9][A-Z][0-9]$`),
+	"DE": regexp.MustCompile(`^[0-9]{5}$`),
+	"FR": regexp.MustCompile(`^[0-9]{5}$`),
+	"NL": regexp.MustCompile(`(?i)^[0-9]{4} ?[A-Z]{2}$`),
+	"JP": regexp.MustCompile(`^[0-9]{3}-?[0-9]{4}$`),
+}
+
+func validatePostcode(parent reflect.Value, field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "postcode=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	name := rule[len("postcode="):]
+	country, ok := siblingValue(parent, name)
+	if !ok {
+		return fmt.Errorf("%w: postcode field %q not found", ErrInvalidRule, name)
+	}
+
+	country = strings.ToUpper(country)
+	re, ok := postcodeRegexps[country]
+	if !ok {
+		return nil
+	}
+
+	if !re.MatchString(field.String()) {
+		return fmt.Errorf("invalid postal code for %s", country)
+	}
+	return nil
+}
+
+func validateSHA256Of(parent reflect.Value, field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "sha256of=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	sibling := parent.FieldByName(rule[len("sha256of="):])
+	if sibling.Kind() == reflect.Ptr && !sibling.IsNil() {
+		sibling = sibling.Elem()
+	}
+
+	var payload []byte
+	switch {
+	case sibling.Kind() == reflect.String:
+		payload = []byte(sibling.String())
+	case sibling.Kind() == reflect.Slice && isByteSlice(sibling):
+		payload = sibling.Bytes()
+	default:
+		return nil
+	}
+
+	sum := sha256.Sum256(payload)
+	if !strings.EqualFold(field.String(), hex.EncodeToString(sum[:])) {
+		return fmt.Errorf("checksum does not match payload")
+	}
+	return nil
+}
+
+type mapThreshold struct {
+	threshold float64
+	maxCount  int
+}
+
+func (t mapThreshold) check(field reflect.Value) error {
+	if field.Kind() == reflect.Ptr && !field.IsNil() {
+		field = field.Elem()
+	}
+
+	var values []reflect.Value
+	switch field.Kind() {
+	case reflect.Map:
+		iter := field.MapRange()
+		for iter.Next() {
+			values = append(values, iter.Value())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < field.Len(); i++ {
+			values = append(values, field.Index(i))
+		}
+	default:
+		return nil
+	}
+
+	exceeding := 0
+	for _, value := range values {
+		if n, ok := numberValue(value); ok && n > t.threshold {
+			exceeding++
+		}
+	}
+
+	if exceeding > t.maxCount {
+		return fmt.Errorf("at most %d values may exceed %s, found %d", t.maxCount, formatFloat(t.threshold), exceeding)
+	}
+	return nil
+}
+
+func numberValue(field reflect.Value) (float64, bool) {
+	switch {
+	case isInt(field):
+		return float64(field.Int()), true
+	case isUint(field):
+		return float64(field.Uint()), true
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		return field.Float(), true
+	}
+	return 0, false
+}
+
+func validateUnique(field reflect.Value, rule string) error {
+	if rule != "unique" || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+	if !field.Type().Elem().Comparable() {
+		return nil
+	}
+
+	seen := make(map[interface{}]int, field.Len())
+	var unhashable []int
+	for i := 0; i < field.Len(); i++ {
+		elem := field.Index(i)
+		value := elem.Interface()
+		if !elem.Comparable() {
+			for _, first := range unhashable {
+				if reflect.DeepEqual(field.Index(first).Interface(), value) {
+					return fmt.Errorf("duplicate value at indices %d and %d", first, i)
+				}
+			}
+			unhashable = append(unhashable, i)
+			continue
+		}
+		if first, ok := seen[value]; ok {
+			return fmt.Errorf("duplicate value at indices %d and %d", first, i)
+		}
+		seen[value] = i
+	}
+	return nil
+}
+
+func validateSubset(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "subset=") || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+
+	allowed := make(map[string]struct{})
+	for _, option := range splitOneOfParams(rule[len("subset="):]) {
+		allowed[option] = struct{}{}
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		value := fmt.Sprint(field.Index(i).Interface())
+		if _, ok := allowed[value]; !ok {
+			return fmt.Errorf("value '%s' is not in the allowed set", value)
+		}
+	}
+	return nil
+}
+
+func validateUniqueDeep(field reflect.Value, rule string) error {
+	if rule != "uniquedeep" || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		for j := i + 1; j < field.Len(); j++ {
+			if reflect.DeepEqual(field.Index(i).Interface(), field.Index(j).Interface()) {
+				return fmt.Errorf("slice contains duplicate elements")
+			}
+		}
+	}
+	return nil
+}
+
+func validateRect(field reflect.Value, rule string) error {
+	if rule != "rect" || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+	if kind := field.Type().Elem().Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil
+	}
+
+	for i := 1; i < field.Len(); i++ {
+		if field.Index(i).Len() != field.Index(0).Len() {
+			return fmt.Errorf("row %d has length %d, expected %d", i, field.Index(i).Len(), field.Index(0).Len())
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateSubsetOf(field reflect.Value, fieldName string, rule string) error {
+	if !strings.HasPrefix(rule, "subsetof=") || (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) {
+		return nil
+	}
+
+	allowed, ok := v.wordSets[rule[len("subsetof="):]]
+	if !ok {
+		return nil
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		if _, ok := allowed[fmt.Sprint(field.Index(i).Interface())]; !ok {
+			return fmt.Errorf("%s contains a value not in the allowed set", strings.ToLower(baseFieldName(fieldName)))
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateCanonical(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "canonical=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	name := rule[len("canonical="):]
+	normalize, ok := v.normalizers[name]
+	if !ok {
+		return nil
+	}
+
+	if normalize(field.String()) != field.String() {
+		return fmt.Errorf("value is not in canonical %s form", name)
+	}
+	return nil
+}
+
+func parseValidationTag(validationTag string) []string {
+	var (
+		rules   []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for i := 0; i < len(validationTag); i++ {
+		c := validationTag[i]
+		switch {
+		case c == '\\' && i+1 < len(validationTag) && validationTag[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case c == '\'':
+			quoted = !quoted
+			current.WriteByte(c)
+		case c == ',' && !quoted:
+			rules = append(rules, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	return append(rules, current.String())
+}
+
+func validateMaxMin(field reflect.Value, rule string) error {
+	if field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64 {
+		return validateFloatMaxMin(field, rule)
+	}
+
+	if isUint(field) {
+		return validateUintMaxMin(field, rule)
+	}
+
+	if isCollection(field) {
+		return validateCollectionMaxMin(field, rule)
+	}
+
+	if strings.HasPrefix(rule, "max=") {
+		max, err := strconv.Atoi(rule[len("max="):])
+		if err == nil && isInt(field) && field.Int() > int64(max) {
+			return newRuleError("max", rule[len("max="):], "value exceeds maximum of %d", max)
+		} else if err == nil && field.Kind() == reflect.String && utf8.RuneCountInString(field.String()) > max {
+			return newRuleError("max", rule[len("max="):], "length exceeds maximum of %d", max)
+		}
+	}
+
+	if strings.HasPrefix(rule, "min=") {
+		min, err := strconv.Atoi(rule[len("min="):])
+		if err == nil && isInt(field) && field.Int() < int64(min) {
+			return newRuleError("min", rule[len("min="):], "value is below minimum of %d", min)
+		} else if err == nil && field.Kind() == reflect.String && utf8.RuneCountInString(field.String()) < min {
+			return newRuleError("min", rule[len("min="):], "length is below minimum of %d", min)
+		}
+	}
+
+	return nil
+}
+
+var comparisonRules = map[string]struct {
+	accept  func(result int) bool
+	message string
+}{
+	"gt":  {func(result int) bool { return result > 0 }, "value must be greater than %s"},
+	"gte": {func(result int) bool { return result >= 0 }, "value must be greater than or equal to %s"},
+	"lt":  {func(result int) bool { return result < 0 }, "value must be less than %s"},
+	"lte": {func(result int) bool { return result <= 0 }, "value must be less than or equal to %s"},
+}
+
+func validateComparison(field reflect.Value, rule string) error {
+	name, bound, _ := strings.Cut(rule, "=")
+	comparison, ok := comparisonRules[name]
+	if !ok {
+		return nil
+	}
+
+	if !isInt(field) && !isUint(field) && field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return fmt.Errorf("%w: rule %s not applicable to %s, use min/max for lengths", ErrInvalidRule, name, field.Kind())
+	}
+
+	result, ok := compareNumber(field, bound)
+	if !ok {
+		return fmt.Errorf("%w: %s bound %q is not a valid %s", ErrInvalidRule, name, bound, field.Kind())
+	}
+
+	if !comparison.accept(result) {
+		return fmt.Errorf(comparison.message, bound)
+	}
+	return nil
+}
+
+func validateEq(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "eq=") {
+		return nil
+	}
+
+	param := rule[len("eq="):]
+	equal, err := equalsParam(field, "eq", param)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return fmt.Errorf("value must equal %s", param)
+	}
+	return nil
+}
+
+func validateNe(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "ne=") {
+		return nil
+	}
+
+	param := rule[len("ne="):]
+	equal, err := equalsParam(field, "ne", param)
+	if err != nil {
+		return err
+	}
+	if equal {
+		return fmt.Errorf("value must not equal %s", param)
+	}
+	return nil
+}
+
+func equalsParam(field reflect.Value, name string, param string) (bool, error) {
+	var (
+		equal bool
+		err   error
+	)
+
+	switch {
+	case field.Kind() == reflect.String:
+		return field.String() == param, nil
+	case field.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(param)
+		equal = field.Bool() == b
+	case isInt(field):
+		var n int64
+		n, err = strconv.ParseInt(param, 10, 64)
+		equal = field.Int() == n
+	case isUint(field):
+		var n uint64
+		n, err = strconv.ParseUint(param, 10, 64)
+		equal = field.Uint() == n
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		var f float64
+		f, err = parseFloatParam(field, param)
+		equal = field.Float() == f
+	default:
+		return false, fmt.Errorf("%w: rule %s not applicable to %s", ErrInvalidRule, name, field.Kind())
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("%w: %s=%s cannot be compared with a %s field", ErrInvalidRule, name, param, field.Kind())
+	}
+	return equal, nil
+}
+
+func compareNumber(field reflect.Value, bound string) (int, bool) {
+	switch {
+	case isInt(field):
+		n, err := strconv.ParseInt(bound, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return cmp.Compare(field.Int(), n), true
+	case isUint(field):
+		n, err := strconv.ParseUint(bound, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return cmp.Compare(field.Uint(), n), true
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		n, err := parseFloatParam(field, bound)
+		if err != nil {
+			return 0, false
+		}
+		return cmp.Compare(field.Float(), n), true
+	}
+	return 0, false
+}
+
+func isInt(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isCollection(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func validateCollectionMaxMin(field reflect.Value, rule string) error {
+	if strings.HasPrefix(rule, "max=") {
+		max, err := strconv.Atoi(rule[len("max="):])
+		if err == nil && field.Len() > max {
+			if isByteSlice(field) {
+				return newRuleError("max", rule[len("max="):], "length must be at most %d", max)
+			}
+			return newRuleError("max", rule[len("max="):], "slice length exceeds maximum of %d", max)
+		}
+	}
+
+	if strings.HasPrefix(rule, "min=") {
+		min, err := strconv.Atoi(rule[len("min="):])
+		if err == nil && field.Len() < min {
+			if isByteSlice(field) {
+				return newRuleError("min", rule[len("min="):], "length must be at least %d", min)
+			}
+			return newRuleError("min", rule[len("min="):], "slice length is below minimum of %d", min)
+		}
+	}
+
+	return nil
+}
+
+func isByteSlice(field reflect.Value) bool {
+	return (field.Kind() == reflect.Slice || field.Kind() == reflect.Array) && field.Type().Elem().Kind() == reflect.Uint8
+}
+
+func isUint(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func validateUintMaxMin(field reflect.Value, rule string) error {
+	if strings.HasPrefix(rule, "max=") {
+		max, err := strconv.ParseUint(rule[len("max="):], 10, 64)
+		if err == nil && field.Uint() > max {
+			return newRuleError("max", rule[len("max="):], "value exceeds maximum of %d", max)
+		}
+	}
+
+	if strings.HasPrefix(rule, "min=") {
+		min, err := strconv.ParseUint(rule[len("min="):], 10, 64)
+		if err == nil && field.Uint() < min {
+			return newRuleError("min", rule[len("min="):], "value is below minimum of %d", min)
+		}
+	}
+
+	return nil
+}
+
+func validateFloatMaxMin(field reflect.Value, rule string) error {
+	bits := field.Type().Bits()
+	if strings.HasPrefix(rule, "max=") {
+		max, err := parseFloatParam(field, rule[len("max="):])
+		if err == nil && field.Float() > max {
+			return newRuleError("max", rule[len("max="):], "value exceeds maximum of %s", strconv.FormatFloat(max, 'f', -1, bits))
+		}
+	}
+
+	if strings.HasPrefix(rule, "min=") {
+		min, err := parseFloatParam(field, rule[len("min="):])
+		if err == nil && field.Float() < min {
+			return newRuleError("min", rule[len("min="):], "value is below minimum of %s", strconv.FormatFloat(min, 'f', -1, bits))
+		}
+	}
+
+	return nil
+}
+
+func parseFloatParam(field reflect.Value, param string) (float64, error) {
+	return strconv.ParseFloat(param, field.Type().Bits())
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func validateLen(field reflect.Value, rule string) error {
+	if strings.HasPrefix(rule, "len=") {
+		expectedLen, err := strconv.Atoi(rule[len("len="):])
+		if err == nil && field.Kind() == reflect.String && utf8.RuneCountInString(field.String()) != expectedLen {
+			return newRuleError("len", rule[len("len="):], "length must be exactly %d", expectedLen)
+		}
+		if err == nil && isByteSlice(field) && field.Len() != expectedLen {
+			return newRuleError("len", rule[len("len="):], "length must be exactly %d", expectedLen)
+		}
+		if err == nil && isCollection(field) && !isByteSlice(field) && field.Len() != expectedLen {
+			return newRuleError("len", rule[len("len="):], "must contain exactly %d elements, got %d", expectedLen, field.Len())
+		}
+	}
+
+	return nil
+}
+
+func validateEmail(field reflect.Value, rule string) error {
+	if rule == "email" && field.Kind() == reflect.String {
+		email := field.String()
+		if !isValidEmail(email) {
+			return newRuleError("email", "", "invalid email format")
+		}
+	}
+	return nil
+}
+
+func validateHex(field reflect.Value, rule string) error {
+	if field.Kind() != reflect.String || (rule != "hex" && !strings.HasPrefix(rule, "hex=")) {
+		return nil
+	}
+
+	decoded, err := hex.DecodeString(field.String())
+	if rule == "hex" {
+		if err != nil {
+			return fmt.Errorf("value must be hex-encoded")
+		}
+		return nil
+	}
+
+	size, convErr := strconv.Atoi(rule[len("hex="):])
+	if convErr != nil {
+		return nil
+	}
+	if err != nil || len(decoded) != size {
+		return fmt.Errorf("value must be %d bytes hex-encoded", size)
+	}
+	return nil
+}
+
+var base32Encodings = map[string]*base32.Encoding{
+	"base32":    base32.StdEncoding,
+	"base32hex": base32.HexEncoding,
+}
+
+func validateBase32(field reflect.Value, rule string) error {
+	encoding, ok := base32Encodings[rule]
+	if !ok || field.Kind() != reflect.String || field.String() == "" {
+		return nil
+	}
+
+	if _, err := encoding.DecodeString(field.String()); err != nil {
+		return fmt.Errorf("value must be valid %s", rule)
+	}
+	return nil
+}
+
+var uuidVersions = map[string]byte{
+	"uuid":  0,
+	"uuid3": '3',
+	"uuid4": '4',
+	"uuid5": '5',
+}
+
+func validateUUID(field reflect.Value, rule string) error {
+	version, ok := uuidVersions[rule]
+	if !ok || field.Kind() != reflect.String {
+		return nil
+	}
+
+	value := field.String()
+	if !uuidRegexp.MatchString(value) || (version != 0 && value[14] != version) {
+		return fmt.Errorf("invalid UUID format")
+	}
+	if rule == "uuid4" && !strings.ContainsRune("89abAB", rune(value[19])) {
+		return fmt.Errorf("invalid UUID format")
+	}
+	return nil
+}
+
+func validateOneOf(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "oneof=") {
+		return nil
+	}
+
+	allowed := splitOneOfParams(rule[len("oneof="):])
+
+	var value string
+	switch {
+	case field.Kind() == reflect.String:
+		value = field.String()
+	case isInt(field):
+		value = strconv.FormatInt(field.Int(), 10)
+	case isUint(field):
+		value = strconv.FormatUint(field.Uint(), 10)
+	default:
+		return nil
+	}
+
+	for _, option := range allowed {
+		if option == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("value must be one of %v", allowed)
+}
+
+func splitOneOfParams(param string) []string {
+	var (
+		values  []string
+		current strings.Builder
+		quote   rune
+		started bool
+	)
+
+	for _, r := range param {
+		switch {
+		case quote == 0 && (r == '\'' || r == '`'):
+			quote = r
+			started = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case r == ' ' && quote == 0:
+			if started {
+				values = append(values, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		values = append(values, current.String())
+	}
+
+	return values
+}
+
+func validateURL(field reflect.Value, rule string) error {
+	if rule == "url" && field.Kind() == reflect.String && field.String() != "" {
+		if !isValidURL(field.String()) {
+			return fmt.Errorf("invalid URL format")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateRegex(field reflect.Value, rule string) error {
+	name, pattern, _ := strings.Cut(rule, "=")
+	if (name != "regex" && name != "regexname") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	re, ok := v.patterns[pattern]
+	if !ok && name == "regexname" {
+		return fmt.Errorf("%w: no regex registered under %q", ErrInvalidRule, pattern)
+	}
+	if !ok {
+		var err error
+		pattern = unquoteParam(pattern)
+		re, err = compileInlineRegex(pattern)
+		if err != nil {
+			return fmt.Errorf("%w: regex pattern %q: %v", ErrInvalidRule, pattern, err)
+		}
+	}
+
+	if !re.MatchString(field.String()) {
+		return fmt.Errorf("value does not match pattern %s", pattern)
+	}
+	return nil
+}
+
+func validateFilePath(field reflect.Value, rule string) error {
+	if (rule != "filepath" && rule != "abspath") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	path := field.String()
+	if path == "" || strings.ContainsRune(path, 0) || (rule == "abspath" && !filepath.IsAbs(path)) {
+		return fmt.Errorf("invalid file path")
+	}
+	return nil
+}
+
+func validateJSONPointer(field reflect.Value, rule string) error {
+	if rule == "jsonpointer" && field.Kind() == reflect.String {
+		if !jsonPointerRegexp.MatchString(field.String()) {
+			return fmt.Errorf("invalid JSON pointer")
+		}
+	}
+	return nil
+}
+
+func validateURLEncoded(field reflect.Value, rule string) error {
+	if rule != "urlencoded" || field.Kind() != reflect.String {
+		return nil
+	}
+
+	decoded, err := url.QueryUnescape(field.String())
+	if err != nil || url.QueryEscape(decoded) != field.String() {
+		return fmt.Errorf("value is not valid URL-encoded text")
+	}
+	return nil
+}
+
+func validateEnvName(field reflect.Value, rule string) error {
+	if rule == "envname" && field.Kind() == reflect.String {
+		if !envNameRegexp.MatchString(field.String()) {
+			return fmt.Errorf("invalid environment variable name")
+		}
+	}
+	return nil
+}
+
+func validateSlug(field reflect.Value, rule string) error {
+	if rule == "slug" && field.Kind() == reflect.String {
+		if !slugRegexp.MatchString(field.String()) {
+			return fmt.Errorf("invalid slug")
+		}
+	}
+	return nil
+}
+
+var characterClasses = map[string]struct {
+	allowed func(r rune) bool
+	message string
+}{
+	"alpha":        {unicode.IsLetter, "value must contain only letters"},
+	"numeric":      {unicode.IsDigit, "value must contain only digits"},
+	"alphanumeric": {func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }, "value must contain only letters and digits"},
+}
+
+func validateCharacterClass(field reflect.Value, rule string) error {
+	class, ok := characterClasses[rule]
+	if !ok || field.Kind() != reflect.String {
+		return nil
+	}
+
+	for _, r := range field.String() {
+		if !class.allowed(r) {
+			return errors.New(class.message)
+		}
+	}
+	return nil
+}
+
+var substringRules = map[string]struct {
+	match   func(s, substr string) bool
+	message string
+}{
+	"contains":   {strings.Contains, "value must contain %s"},
+	"startswith": {strings.HasPrefix, "value must start with %s"},
+	"endswith":   {strings.HasSuffix, "value must end with %s"},
+}
+
+func validateSubstring(field reflect.Value, rule string) error {
+	name, param, _ := strings.Cut(rule, "=")
+	substring, ok := substringRules[name]
+	if !ok || field.Kind() != reflect.String {
+		return nil
+	}
+
+	param = unquoteParam(param)
+	if !substring.match(field.String(), param) {
+		return fmt.Errorf(substring.message, param)
+	}
+	return nil
+}
+
+func unquoteParam(param string) string {
+	if len(param) >= 2 && param[0] == '\'' && param[len(param)-1] == '\'' {
+		return param[1 : len(param)-1]
+	}
+	return param
+}
+
+var semverOperators = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+func validateSemverRange(field reflect.Value, rule string) error {
+	if rule != "semverrange" || field.Kind() != reflect.String {
+		return nil
+	}
+
+	if !isSemverRange(field.String()) {
+		return fmt.Errorf("invalid version constraint")
+	}
+	return nil
+}
+
+func isSemverRange(constraint string) bool {
+	for _, set := range strings.Split(constraint, "||") {
+		tokens := strings.Fields(set)
+		if len(tokens) == 0 {
+			return false
+		}
+
+		if len(tokens) == 3 && tokens[1] == "-" {
+			if !semverPartialRegexp.MatchString(tokens[0]) || !semverPartialRegexp.MatchString(tokens[2]) {
+				return false
+			}
+			continue
+		}
+
+		for i := 0; i < len(tokens); i++ {
+			version := tokens[i]
+			for _, op := range semverOperators {
+				if strings.HasPrefix(version, op) {
+					version = version[len(op):]
+					break
+				}
+			}
+			if version == "" && i+1 < len(tokens) {
+				i++
+				version = tokens[i]
+			}
+			if !semverPartialRegexp.MatchString(version) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true,
+	"default": true, "defer": true, "else": true, "fallthrough": true, "for": true,
+	"func": true, "go": true, "goto": true, "if": true, "import": true,
+	"interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+func validateGoIdentifier(field reflect.Value, rule string) error {
+	if rule != "goidentifier" || field.Kind() != reflect.String {
+		return nil
+	}
+
+	name := field.String()
+	valid := name != "" && !goKeywords[name]
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			valid = false
+			break
+		}
+	}
+
+	if !valid {
+		return fmt.Errorf("value is not a valid Go identifier")
+	}
+	return nil
+}
+
+var inlineRegexCache sync.Map
+
+func compileInlineRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := inlineRegexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	inlineRegexCache.Store(pattern, re)
+	return re, nil
+}
+
+func validateRegexSyntax(field reflect.Value, rule string) error {
+	if rule == "regex_syntax" && field.Kind() == reflect.String {
+		if _, err := regexp.Compile(field.String()); err != nil {
+			return fmt.Errorf("value is not a valid regular expression")
+		}
+	}
+	return nil
+}
+
+func validateMaxWidth(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "maxwidth=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	max, err := strconv.Atoi(rule[len("maxwidth="):])
+	if err == nil && displayWidth(field.String()) > max {
+		return fmt.Errorf("value exceeds maximum display width of %d", max)
+	}
+	return nil
+}
+
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
+
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	for _, wide := range wideRanges {
+		if r >= wide.lo && r <= wide.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+func validateDatetimeAny(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "datetime_any=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	for _, layout := range strings.Split(rule[len("datetime_any="):], "|") {
+		if _, err := time.Parse(layout, field.String()); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("value does not match any accepted date format")
+}
+
+func validateWeekday(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "weekday=") || field.Type() != timeType {
+		return nil
+	}
+
+	weekday := field.Interface().(time.Time).Weekday()
+	for _, name := range strings.Fields(rule[len("weekday="):]) {
+		if day, ok := parseWeekday(name); ok && day == weekday {
+			return nil
+		}
+	}
+	return fmt.Errorf("date must fall on an allowed weekday")
+}
+
+func (v *Validator) validateBusinessDay(field reflect.Value, rule string) error {
+	if (rule != "weekday" && rule != "businessday") || field.Type() != timeType || !field.CanInterface() {
+		return nil
+	}
+
+	date := field.Interface().(time.Time)
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return fmt.Errorf("date must be a weekday")
+	}
+
+	if rule == "businessday" {
+		if _, ok := v.holidays[date.Format(time.DateOnly)]; ok {
+			return fmt.Errorf("date must be a business day")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateToday(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "today=") || field.Kind() != reflect.String {
+		return nil
+	}
+
+	layout := rule[len("today="):]
+	date, err := time.Parse(layout, field.String())
+	if err != nil {
+		return fmt.Errorf("value must be today's date")
+	}
+
+	now := v.now()
+	if date.Format(layout) != now.Format(layout) {
+		return fmt.Errorf("value must be today's date")
+	}
+	return nil
+}
+
+func (v *Validator) validateTime(field reflect.Value, rule string) error {
+	name, param, _ := strings.Cut(rule, "=")
+
+	if name == "datetime" {
+		if field.Kind() != reflect.String {
+			return nil
+		}
+		if _, err := time.Parse(param, field.String()); err != nil {
+			return fmt.Errorf("value must be a datetime in layout %s", param)
+		}
+		return nil
+	}
+
+	if (name != "before" && name != "after") || field.Type() != timeType || !field.CanInterface() {
+		return nil
+	}
+
+	bound := v.now()
+	if param != "now" {
+		var err error
+		bound, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return fmt.Errorf("%w: %s=%s must be now or an RFC 3339 time", ErrInvalidRule, name, param)
+		}
+	}
+
+	value := field.Interface().(time.Time)
+	if name == "before" && !value.Before(bound) {
+		return fmt.Errorf("time must be before %s", param)
+	}
+	if name == "after" && !value.After(bound) {
+		return fmt.Errorf("time must be after %s", param)
+	}
+	return nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+var parseIntRules = map[string]struct {
+	signed  bool
+	bitSize int
+}{
+	"parseint":    {true, 0},
+	"parseint8":   {true, 8},
+	"parseint16":  {true, 16},
+	"parseint32":  {true, 32},
+	"parseint64":  {true, 64},
+	"parseuint":   {false, 0},
+	"parseuint8":  {false, 8},
+	"parseuint16": {false, 16},
+	"parseuint32": {false, 32},
+	"parseuint64": {false, 64},
+}
+
+func validateParseInt(field reflect.Value, rule string) error {
+	target, ok := parseIntRules[rule]
+	if !ok || field.Kind() != reflect.String {
+		return nil
+	}
+
+	var err error
+	if target.signed {
+		_, err = strconv.ParseInt(field.String(), 10, target.bitSize)
+	} else {
+		_, err = strconv.ParseUint(field.String(), 10, target.bitSize)
+	}
+
+	if err != nil {
+		return fmt.Errorf("value must be a valid %s", rule[len("parse"):])
+	}
+	return nil
+}
+
+type integerBounds struct {
+	min int64
+	max uint64
+}
+
+var integerTypeBounds = map[string]integerBounds{
+	"int":    {math.MinInt, math.MaxInt},
+	"int8":   {math.MinInt8, math.MaxInt8},
+	"int16":  {math.MinInt16, math.MaxInt16},
+	"int32":  {math.MinInt32, math.MaxInt32},
+	"int64":  {math.MinInt64, math.MaxInt64},
+	"uint":   {0, math.MaxUint},
+	"uint8":  {0, math.MaxUint8},
+	"uint16": {0, math.MaxUint16},
+	"uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxUint64},
+}
+
+func validateFits(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "fits=") {
+		return nil
+	}
+
+	target := rule[len("fits="):]
+	bounds, ok := integerTypeBounds[target]
+	if !ok {
+		return nil
+	}
+
+	fits := true
+	switch {
+	case isInt(field):
+		value := field.Int()
+		fits = value >= bounds.min && (value < 0 || uint64(value) <= bounds.max)
+	case isUint(field):
+		fits = field.Uint() <= bounds.max
+	}
+
+	if !fits {
+		return fmt.Errorf("value does not fit in %s", target)
+	}
+	return nil
+}
+
+func validateFlags(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "flags=") {
+		return nil
+	}
+
+	var allowedMask uint64
+	for _, flag := range strings.Fields(rule[len("flags="):]) {
+		bit, err := strconv.ParseUint(flag, 0, 64)
+		if err != nil {
+			return fmt.Errorf("%w: flags value %q is not an integer", ErrInvalidRule, flag)
+		}
+		allowedMask |= bit
+	}
+
+	var value uint64
+	switch {
+	case isInt(field):
+		value = uint64(field.Int())
+	case isUint(field):
+		value = field.Uint()
+	default:
+		return nil
+	}
+
+	if value&^allowedMask != 0 {
+		return fmt.Errorf("value contains disallowed flag bits")
+	}
+	return nil
+}
+
+func validatePowerOf2(field reflect.Value, rule string) error {
+	if rule != "powerof2" {
+		return nil
+	}
+
+	var isPowerOf2 bool
+	switch {
+	case isInt(field):
+		value := field.Int()
+		isPowerOf2 = value > 0 && value&(value-1) == 0
+	case isUint(field):
+		value := field.Uint()
+		isPowerOf2 = value > 0 && value&(value-1) == 0
+	default:
+		return nil
+	}
+
+	if !isPowerOf2 {
+		return fmt.Errorf("value must be a power of two")
+	}
+	return nil
+}
+
+func validateLuhn(field reflect.Value, rule string) error {
+	if rule != "luhn" || field.Kind() != reflect.String {
+		return nil
+	}
+
+	digits := strings.ReplaceAll(field.String(), " ", "")
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '0' || digits[i] > '9' {
+			return fmt.Errorf("value fails Luhn check")
+		}
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	if digits == "" || sum%10 != 0 {
+		return fmt.Errorf("value fails Luhn check")
+	}
+	return nil
+}
+
+func validateIncrement(field reflect.Value, rule string) error {
+	if !strings.HasPrefix(rule, "increment=") {
+		return nil
+	}
+	if field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return nil
+	}
+
+	param := rule[len("increment="):]
+	step, err := strconv.ParseFloat(param, 64)
+	if err != nil || step <= 0 {
+		return fmt.Errorf("%w: increment step %q must be a positive number", ErrInvalidRule, param)
+	}
+
+	steps := field.Float() / step
+	if math.Abs(steps-math.Round(steps)) > 1e-9*math.Max(1, math.Abs(steps)) {
+		return fmt.Errorf("value must be a multiple of %s", param)
+	}
+	return nil
+}
+
+func validateDigits(field reflect.Value, rule string) error {
+	name, param, _ := strings.Cut(rule, "=")
+	if name != "digits" && name != "digits_between" {
+		return nil
+	}
+
+	var digits string
+	switch {
+	case isInt(field):
+		digits = strings.TrimPrefix(strconv.FormatInt(field.Int(), 10), "-")
+	case isUint(field):
+		digits = strconv.FormatUint(field.Uint(), 10)
+	case field.Kind() == reflect.String:
+		digits = strings.TrimLeft(field.String(), "+-")
+		if strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			return fmt.Errorf("value must contain only digits")
+		}
+	default:
+		return nil
+	}
+
+	if name == "digits" {
+		expected, err := strconv.Atoi(param)
+		if err == nil && len(digits) != expected {
+			return fmt.Errorf("value must have exactly %d digits", expected)
+		}
+		return nil
+	}
+
+	minParam, maxParam, ok := strings.Cut(strings.Trim(param, "'"), ",")
+	min, minErr := strconv.Atoi(strings.TrimSpace(minParam))
+	max, maxErr := strconv.Atoi(strings.TrimSpace(maxParam))
+	if !ok || minErr != nil || maxErr != nil {
+		return fmt.Errorf("%w: digits_between=%s, expected MIN,MAX", ErrInvalidRule, param)
+	}
+	if len(digits) < min || len(digits) > max {
+		return fmt.Errorf("value must have between %d and %d digits", min, max)
+	}
+	return nil
+}
+
+func isZeroValue(field reflect.Value) bool {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return true
+		}
+		field = field.Elem()
+	}
+
+	return (field.Kind() == reflect.String && field.String() == "") ||
+		(field.Kind() == reflect.Int && field.Int() == 0) ||
+		((field.Kind() == reflect.Slice || field.Kind() == reflect.Map) && field.Len() == 0)
+}
+
+func (v *Validator) isMissing(field reflect.Value) bool {
+	if v.nilOnlyCollections && (field.Kind() == reflect.Slice || field.Kind() == reflect.Map) {
+		return field.IsNil()
+	}
+	return isZeroValue(field)
+}
+
+var emailRegexp = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+func isValidEmail(email string) bool {
+	return emailRegexp.MatchString(email)
+}
+
+var uuidRegexp = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+var jsonPointerRegexp = regexp.MustCompile(`^(/([^~/]|~[01])*)*$`)
+
+var envNameRegexp = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+var semverPartialRegexp = regexp.MustCompile(`^v?(0|[1-9][0-9]*|[xX*])(\.(0|[1-9][0-9]*|[xX*])){0,2}(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func isValidURL(rawURL string) bool {
+	u, err := url.ParseRequestURI(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
@@ -1,9 +1,24 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
+func validationMessage(err error) string {
+	if validationErr, ok := err.(*ValidationError); ok {
+		return string(validationErr.Message)
+	}
+	return err.Error()
+}
+
 type User struct {
 	Name    *string `validate:"required,min=3,max=50"`
 	Email   string  `validate:"required,email"`
@@ -179,3 +194,3429 @@ func TestMaxMinValidation(t *testing.T) {
 		t.Log("Validation passed (Age valid)!")
 	}
 }
+
+type Record struct {
+	Value int64  `validate:"fits=int32"`
+	Count uint64 `validate:"fits=uint8"`
+}
+
+func TestFitsValidation(t *testing.T) {
+	validator := New()
+
+	record := Record{Value: math.MaxInt32, Count: 255}
+	if err := validator.Validate(record); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	record.Value = math.MaxInt32 + 1
+	err := validator.Validate(record)
+	if err == nil {
+		t.Errorf("Expected 'value does not fit in int32' error, but got none")
+	} else if validationMessage(err) != "value does not fit in int32" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	record.Value = math.MinInt32 - 1
+	if err := validator.Validate(record); err == nil {
+		t.Errorf("Expected underflow to fail fits=int32, but got none")
+	}
+
+	record.Value = 0
+	record.Count = 256
+	if err := validator.Validate(record); err == nil {
+		t.Errorf("Expected 'value does not fit in uint8' error, but got none")
+	}
+}
+
+type Product struct {
+	Price    float64 `validate:"min=0,max=9999.99"`
+	Discount float32 `validate:"min=-50.5,max=0"`
+}
+
+func TestFloatMaxMinValidation(t *testing.T) {
+	validator := New()
+
+	product := Product{Price: 9999.99, Discount: -50.5}
+	if err := validator.Validate(product); err != nil {
+		t.Errorf("Expected boundary values to pass, but got: %s", err)
+	}
+
+	product.Price = 10000
+	err := validator.Validate(product)
+	if err == nil {
+		t.Errorf("Expected 'value exceeds maximum of 9999.99' error, but got none")
+	} else if validationMessage(err) != "value exceeds maximum of 9999.99" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	product.Price = -0.01
+	err = validator.Validate(product)
+	if err == nil {
+		t.Errorf("Expected 'value is below minimum of 0' error, but got none")
+	} else if validationMessage(err) != "value is below minimum of 0" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	product.Price = 10
+	product.Discount = -51
+	err = validator.Validate(product)
+	if err == nil {
+		t.Errorf("Expected 'value is below minimum of -50.5' error, but got none")
+	} else if validationMessage(err) != "value is below minimum of -50.5" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Gauge struct {
+	Level  float32 `validate:"min=0.1,max=9999.99"`
+	Ratio  float32 `validate:"gte=0.1,lte=0.3"`
+	Factor float32 `validate:"eq=0.1"`
+}
+
+func TestFloat32InexactBounds(t *testing.T) {
+	validator := New()
+
+	gauge := Gauge{Level: 9999.99, Ratio: 0.3, Factor: 0.1}
+	if err := validator.Validate(gauge); err != nil {
+		t.Errorf("Expected float32 values equal to inexact bounds to pass, but got: %s", err)
+	}
+
+	gauge = Gauge{Level: 0.1, Ratio: 0.1, Factor: 0.1}
+	if err := validator.Validate(gauge); err != nil {
+		t.Errorf("Expected float32 values equal to inexact lower bounds to pass, but got: %s", err)
+	}
+
+	gauge.Level = 10000
+	err := validator.Validate(gauge)
+	if err == nil {
+		t.Errorf("Expected 'value exceeds maximum of 9999.99' error, but got none")
+	} else if validationMessage(err) != "value exceeds maximum of 9999.99" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	gauge = Gauge{Level: 20000, Ratio: 0.3, Factor: 0.1}
+	if _, err := validator.ValidateAndFix(&gauge); err != nil {
+		t.Errorf("Expected no validation errors after clamping, but got: %s", err)
+	}
+	if gauge.Level != 9999.99 {
+		t.Errorf("Expected Level to be clamped to 9999.99, but got: %v", gauge.Level)
+	}
+}
+
+func TestCaseInsensitiveFieldKeys(t *testing.T) {
+	var name string = "John Doe"
+	user := User{
+		Name:    &name,
+		Email:   "",
+		Age:     25,
+		Address: "1234567890",
+	}
+
+	customErrors := CustomErrors{
+		"email": {
+			"required": "Email is required",
+		},
+	}
+
+	err := New().WithCustomErrors(customErrors).Validate(user)
+	if err == nil {
+		t.Fatalf("Expected validation error, but got none")
+	}
+	if err.Error() == "Field 'Email' validation failed: Email is required" {
+		t.Errorf("Expected lowercase key to be ignored by default, but got: %s", err)
+	}
+
+	err = New().WithCustomErrors(customErrors).CaseInsensitiveFieldKeys().Validate(user)
+	if err == nil {
+		t.Fatalf("Expected validation error, but got none")
+	}
+	if err.Error() != "Field 'Email' validation failed: Email is required" {
+		t.Errorf("Expected custom error for lowercase key, but got: %s", err)
+	}
+}
+
+type Event struct {
+	CreatedUnix int64 `validate:"min=0,max=4102444800"`
+	Priority    int8  `validate:"min=1,max=5"`
+}
+
+func TestSignedIntegerKindsMaxMin(t *testing.T) {
+	validator := New()
+
+	event := Event{CreatedUnix: 1700000000, Priority: 3}
+	if err := validator.Validate(event); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	event.CreatedUnix = 4102444801
+	err := validator.Validate(event)
+	if err == nil {
+		t.Errorf("Expected int64 field to exceed max, but got no error")
+	} else if validationMessage(err) != "value exceeds maximum of 4102444800" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	event.CreatedUnix = -1
+	if err := validator.Validate(event); err == nil {
+		t.Errorf("Expected int64 field below min to fail, but got no error")
+	}
+
+	event.CreatedUnix = 0
+	event.Priority = 6
+	if err := validator.Validate(event); err == nil {
+		t.Errorf("Expected int8 field to exceed max, but got no error")
+	}
+}
+
+type Permissions struct {
+	Mode int `validate:"flags=1 2 4 8"`
+}
+
+func TestFlagsValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Permissions{Mode: 1 | 2}); err != nil {
+		t.Errorf("Expected 3 to pass flags=1 2 4 8, but got: %s", err)
+	}
+
+	err := validator.Validate(Permissions{Mode: 16})
+	if err == nil {
+		t.Errorf("Expected 16 to fail flags=1 2 4 8, but got no error")
+	} else if validationMessage(err) != "value contains disallowed flag bits" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Order struct {
+	Quantity uint   `validate:"min=1,max=100"`
+	Weight   uint16 `validate:"max=-1"`
+}
+
+func TestUnsignedIntegerMaxMin(t *testing.T) {
+	validator := New()
+
+	order := Order{Quantity: 100, Weight: 500}
+	if err := validator.Validate(order); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	order.Quantity = 5000
+	err := validator.Validate(order)
+	if err == nil {
+		t.Errorf("Expected uint field to exceed max, but got no error")
+	} else if validationMessage(err) != "value exceeds maximum of 100" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	order.Quantity = 0
+	if err := validator.Validate(order); err == nil {
+		t.Errorf("Expected uint field below min to fail, but got no error")
+	}
+}
+
+type Range struct {
+	Count int     `validate:"gt=5,lt=10"`
+	Size  uint    `validate:"gt=0"`
+	Ratio float64 `validate:"gt=0,lt=1"`
+}
+
+func TestGtLtValidation(t *testing.T) {
+	validator := New()
+
+	r := Range{Count: 6, Size: 1, Ratio: 0.5}
+	if err := validator.Validate(r); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	r.Count = 5
+	err := validator.Validate(r)
+	if err == nil {
+		t.Errorf("Expected 'value must be greater than 5' error, but got none")
+	} else if validationMessage(err) != "value must be greater than 5" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	r.Count = 10
+	err = validator.Validate(r)
+	if err == nil {
+		t.Errorf("Expected 'value must be less than 10' error, but got none")
+	} else if validationMessage(err) != "value must be less than 10" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	r.Count = 7
+	r.Size = 0
+	if err := validator.Validate(r); err == nil {
+		t.Errorf("Expected uint field equal to gt bound to fail, but got no error")
+	}
+
+	r.Size = 1
+	r.Ratio = 1
+	if err := validator.Validate(r); err == nil {
+		t.Errorf("Expected float field equal to lt bound to fail, but got no error")
+	}
+}
+
+func TestGtLtCustomErrors(t *testing.T) {
+	validator := New().WithCustomErrors(CustomErrors{
+		"Count": {
+			"gt": "Count must be more than five",
+			"lt": "Count must be less than ten",
+		},
+	})
+
+	err := validator.Validate(Range{Count: 1, Size: 1, Ratio: 0.5})
+	if err == nil || err.Error() != "Field 'Count' validation failed: Count must be more than five" {
+		t.Errorf("Expected custom gt error, but got: %v", err)
+	}
+
+	err = validator.Validate(Range{Count: 12, Size: 1, Ratio: 0.5})
+	if err == nil || err.Error() != "Field 'Count' validation failed: Count must be less than ten" {
+		t.Errorf("Expected custom lt error, but got: %v", err)
+	}
+}
+
+type Checksum struct {
+	Digest string `validate:"hex=32"`
+	Salt   string `validate:"hex"`
+}
+
+func TestHexValidation(t *testing.T) {
+	validator := New()
+	digest := strings.Repeat("ab", 32)
+
+	if err := validator.Validate(Checksum{Digest: digest, Salt: "00ff"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(Checksum{Digest: strings.Repeat("ab", 31), Salt: "00ff"})
+	if err == nil {
+		t.Errorf("Expected wrong length digest to fail, but got no error")
+	} else if validationMessage(err) != "value must be 32 bytes hex-encoded" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Checksum{Digest: strings.Repeat("zz", 32), Salt: "00ff"}); err == nil {
+		t.Errorf("Expected non-hex digest to fail, but got no error")
+	}
+
+	err = validator.Validate(Checksum{Digest: digest, Salt: "xyz"})
+	if err == nil {
+		t.Errorf("Expected non-hex salt to fail, but got no error")
+	} else if validationMessage(err) != "value must be hex-encoded" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Enrollment struct {
+	StudentID int
+	CourseID  string
+	Grade     string
+}
+
+func TestValidateUniqueComposite(t *testing.T) {
+	validator := New()
+	batch := []Enrollment{
+		{StudentID: 1, CourseID: "MATH101", Grade: "A"},
+		{StudentID: 1, CourseID: "CS101", Grade: "B"},
+		{StudentID: 2, CourseID: "MATH101", Grade: "C"},
+		{StudentID: 1, CourseID: "MATH101", Grade: "F"},
+	}
+
+	seen := make(map[string]bool)
+	var duplicates []int
+	for i := range batch {
+		if err := validator.ValidateUniqueComposite(&batch[i], []string{"StudentID", "CourseID"}, seen); err != nil {
+			t.Log("Validation Error (duplicate composite key):", err)
+			duplicates = append(duplicates, i)
+		}
+	}
+
+	if len(duplicates) != 1 || duplicates[0] != 3 {
+		t.Errorf("Expected only record 3 to be a duplicate, but got: %v", duplicates)
+	}
+
+	if err := validator.ValidateUniqueComposite(batch[0], []string{"Missing"}, seen); err == nil {
+		t.Errorf("Expected error for unknown key field, but got none")
+	}
+
+	var missing *Enrollment
+	if err := validator.ValidateUniqueComposite(missing, []string{"StudentID"}, seen); err == nil || err.Error() != "validate: expected struct, got nil *validator.Enrollment" {
+		t.Errorf("Expected nil pointer error, but got: %v", err)
+	}
+	if err := validator.ValidateUniqueComposite(42, []string{"StudentID"}, seen); err == nil {
+		t.Errorf("Expected error for non-struct input, but got none")
+	}
+}
+
+type Booking struct {
+	_     struct{} `validate:"maxspan=Start End 720h"`
+	Start time.Time
+	End   time.Time
+}
+
+func TestMaxSpanValidation(t *testing.T) {
+	validator := New()
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	booking := Booking{Start: start, End: start.AddDate(0, 0, 10)}
+	if err := validator.Validate(booking); err != nil {
+		t.Errorf("Expected 10-day span to pass, but got: %s", err)
+	}
+
+	booking.End = start.AddDate(0, 0, 40)
+	err := validator.Validate(booking)
+	if err == nil {
+		t.Errorf("Expected 40-day span to fail, but got no error")
+	} else if validationMessage(err) != "Start and End must be within 720h" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Post struct {
+	Title string   `validate:"min=1,max=5"`
+	Tags  []string `validate:"min=1,max=5"`
+}
+
+func TestSliceMaxMinValidation(t *testing.T) {
+	validator := New()
+
+	post := Post{Title: "Hello", Tags: []string{"go"}}
+	if err := validator.Validate(post); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	post.Tags = []string{}
+	err := validator.Validate(post)
+	if err == nil {
+		t.Errorf("Expected empty slice to fail min=1, but got no error")
+	} else if validationMessage(err) != "slice length is below minimum of 1" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	post.Tags = []string{"a", "b", "c", "d", "e", "f"}
+	err = validator.Validate(post)
+	if err == nil {
+		t.Errorf("Expected over-full slice to fail max=5, but got no error")
+	} else if validationMessage(err) != "slice length exceeds maximum of 5" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	post.Tags = []string{"a"}
+	post.Title = "Too long"
+	err = validator.Validate(post)
+	if err == nil || validationMessage(err) != "length exceeds maximum of 5" {
+		t.Errorf("Expected string length error, but got: %v", err)
+	}
+}
+
+type Profile struct {
+	Email string `validate:"required,email"`
+	Bio   string `validate:"max=10"`
+}
+
+type Account struct {
+	Username string `validate:"required"`
+	Profile  Profile
+	Backup   *Profile `validate:"required"`
+	Created  time.Time
+}
+
+func TestNestedStructValidation(t *testing.T) {
+	validator := New()
+	account := Account{
+		Username: "jdoe",
+		Profile:  Profile{Email: "john@example.com"},
+		Backup:   &Profile{Email: "backup@example.com"},
+	}
+
+	if err := validator.Validate(account); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	account.Profile.Email = "invalid"
+	err := validator.Validate(account)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError for nested field, but got: %v", err)
+	}
+	if validationErr.Field != "Profile.Email" {
+		t.Errorf("Expected field 'Profile.Email', but got: %s", validationErr.Field)
+	}
+
+	account.Profile.Email = "john@example.com"
+	account.Backup.Bio = "This bio is far too long"
+	err = validator.Validate(account)
+	validationErr, ok = err.(*ValidationError)
+	if !ok || validationErr.Field != "Backup.Bio" {
+		t.Errorf("Expected error on 'Backup.Bio' through pointer, but got: %v", err)
+	}
+
+	account.Backup = nil
+	err = validator.Validate(account)
+	validationErr, ok = err.(*ValidationError)
+	if !ok || validationErr.Field != "Backup" || validationErr.Message != "field is required" {
+		t.Errorf("Expected required error on nil 'Backup', but got: %v", err)
+	}
+}
+
+func TestNestedStructCustomErrors(t *testing.T) {
+	validator := New().WithCustomErrors(CustomErrors{
+		"Profile.Email": {
+			"required": "Profile email is required",
+		},
+	})
+
+	account := Account{
+		Username: "jdoe",
+		Backup:   &Profile{Email: "backup@example.com"},
+	}
+
+	err := validator.Validate(account)
+	if err == nil || err.Error() != "Field 'Profile.Email' validation failed: Profile email is required" {
+		t.Errorf("Expected custom error for nested field, but got: %v", err)
+	}
+}
+
+type Article struct {
+	Tags   []string `validate:"required,dive,min=2,max=20"`
+	Scores [3]int   `validate:"dive,max=10"`
+}
+
+func TestDiveValidation(t *testing.T) {
+	validator := New()
+
+	article := Article{Tags: []string{"go", "validation"}, Scores: [3]int{1, 5, 10}}
+	if err := validator.Validate(article); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	article.Tags = nil
+	err := validator.Validate(article)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Tags" {
+		t.Errorf("Expected required error on 'Tags', but got: %v", err)
+	}
+
+	article.Tags = []string{"go", "ok", "x"}
+	err = validator.Validate(article)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError for element, but got: %v", err)
+	}
+	if validationErr.Field != "Tags[2]" || validationErr.Message != "length is below minimum of 2" {
+		t.Errorf("Expected 'Tags[2]' min error, but got: %s", validationErr)
+	}
+
+	article.Tags = []string{"go"}
+	article.Scores[1] = 11
+	err = validator.Validate(article)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Scores[1]" {
+		t.Errorf("Expected error on array element 'Scores[1]', but got: %v", err)
+	}
+}
+
+func TestValidateAndFix(t *testing.T) {
+	var name string = "John Doe"
+	user := User{
+		Name:    &name,
+		Email:   "john@example.com",
+		Age:     150,
+		Address: "1234567890",
+	}
+
+	fixed, err := New().ValidateAndFix(&user)
+	if err != nil {
+		t.Errorf("Expected no validation errors after clamping, but got: %s", err)
+	}
+	if user.Age != 100 {
+		t.Errorf("Expected Age to be clamped to 100, but got: %d", user.Age)
+	}
+	if len(fixed) != 1 || fixed[0] != "Age" {
+		t.Errorf("Expected only 'Age' to be reported as fixed, but got: %v", fixed)
+	}
+
+	product := Product{Price: -5, Discount: -60}
+	fixed, err = New().ValidateAndFix(&product)
+	if err != nil {
+		t.Errorf("Expected no validation errors after clamping, but got: %s", err)
+	}
+	if product.Price != 0 || product.Discount != -50.5 || len(fixed) != 2 {
+		t.Errorf("Expected float fields to be clamped, but got: %+v (fixed %v)", product, fixed)
+	}
+
+	if _, err := New().ValidateAndFix(user); err == nil {
+		t.Errorf("Expected error when passing a non-pointer, but got none")
+	}
+}
+
+type Listing struct {
+	Category string `validate:"inkeysof=categoryMap"`
+}
+
+func TestInKeysOfValidation(t *testing.T) {
+	categoryMap := map[string]string{
+		"books": "Books",
+		"music": "Music & Audio",
+	}
+	validator := New().RegisterKeySet("categoryMap", categoryMap)
+
+	if err := validator.Validate(Listing{Category: "music"}); err != nil {
+		t.Errorf("Expected registered key to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Listing{Category: "Music & Audio"})
+	if err == nil {
+		t.Errorf("Expected label (not key) to fail, but got no error")
+	} else if validationMessage(err) != "invalid category" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	validator.WithCustomErrors(CustomErrors{"Category": {"inkeysof": "Pick a category from the list"}})
+	err = validator.Validate(Listing{Category: "films"})
+	if err == nil || validationMessage(err) != "Pick a category from the list" {
+		t.Errorf("Expected custom inkeysof message, but got: %v", err)
+	}
+}
+
+type Mailing struct {
+	Emails     []string  `validate:"dive,email"`
+	Recipients []Profile `validate:"dive"`
+	Notes      []string  `validate:"dive"`
+}
+
+func TestValidateAllDiveElements(t *testing.T) {
+	type Part struct {
+		SKU string `validate:"required"`
+	}
+	type Shipment struct {
+		Parts  []Part         `validate:"dive"`
+		Codes  []string       `validate:"dive,min=2"`
+		Counts map[string]int `validate:"dive,max=5"`
+	}
+
+	err := New().ValidateAll(Shipment{
+		Parts:  []Part{{}, {SKU: "a"}, {}},
+		Codes:  []string{"x", "ok", "y"},
+		Counts: map[string]int{"a": 9, "b": 1, "c": 7},
+	})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, but got: %v", err)
+	}
+
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.(*ValidationError).Field)
+	}
+	if strings.Join(fields, ",") != "Parts[0].SKU,Parts[2].SKU,Codes[0],Codes[2],Counts[a],Counts[c]" {
+		t.Errorf("Expected every failing element to be reported, but got: %v", fields)
+	}
+
+	err = New().Validate(Shipment{Parts: []Part{{}, {}}})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Parts[0].SKU" {
+		t.Errorf("Expected Validate to stop at the first element, but got: %v", err)
+	}
+}
+
+func TestDiveElementValidation(t *testing.T) {
+	validator := New()
+	mailing := Mailing{
+		Emails:     []string{"a@example.com", "not-an-email", "c@example.com"},
+		Recipients: []Profile{{Email: "r@example.com"}},
+		Notes:      []string{""},
+	}
+
+	err := validator.Validate(mailing)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError for element, but got: %v", err)
+	}
+	if validationErr.Field != "Emails[1]" || validationErr.Message != "invalid email format" {
+		t.Errorf("Expected email error on 'Emails[1]', but got: %s", validationErr)
+	}
+
+	mailing.Emails[1] = "b@example.com"
+	if err := validator.Validate(mailing); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	mailing.Recipients = append(mailing.Recipients, Profile{Email: "broken"})
+	err = validator.Validate(mailing)
+	validationErr, ok = err.(*ValidationError)
+	if !ok || validationErr.Field != "Recipients[1].Email" {
+		t.Errorf("Expected error on 'Recipients[1].Email', but got: %v", err)
+	}
+}
+
+type Label struct {
+	Text string `validate:"maxwidth=6"`
+}
+
+func TestMaxWidthValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Label{Text: "abcd"}); err != nil {
+		t.Errorf("Expected 4 ASCII characters to fit width 6, but got: %s", err)
+	}
+
+	err := validator.Validate(Label{Text: "日本語で"})
+	if err == nil {
+		t.Errorf("Expected 4 wide characters to exceed width 6, but got no error")
+	} else if validationMessage(err) != "value exceeds maximum display width of 6" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Label{Text: "日本語"}); err != nil {
+		t.Errorf("Expected 3 wide characters to fit width 6, but got: %s", err)
+	}
+}
+
+type Filter struct {
+	Pattern string `validate:"regex_syntax"`
+}
+
+func TestRegexSyntaxValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Filter{Pattern: `^[a-z]+\d*$`}); err != nil {
+		t.Errorf("Expected valid pattern to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Filter{Pattern: "("})
+	if err == nil {
+		t.Errorf("Expected '(' to fail regex_syntax, but got no error")
+	} else if validationMessage(err) != "value is not a valid regular expression" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Batch struct {
+	Size    int    `validate:"even"`
+	Version string `validate:"prefix=v"`
+}
+
+func TestRegisterRule(t *testing.T) {
+	validator := New().
+		RegisterRule("even", func(fv reflect.Value, param string) error {
+			if fv.Kind() == reflect.Int && fv.Int()%2 != 0 {
+				return fmt.Errorf("value must be even")
+			}
+			return nil
+		}).
+		RegisterRule("prefix", func(fv reflect.Value, param string) error {
+			if !strings.HasPrefix(fv.String(), param) {
+				return fmt.Errorf("value must start with %s", param)
+			}
+			return nil
+		})
+
+	if err := validator.Validate(Batch{Size: 4, Version: "v1"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(Batch{Size: 3, Version: "v1"})
+	if err == nil || validationMessage(err) != "value must be even" {
+		t.Errorf("Expected custom 'even' rule to fail, but got: %v", err)
+	}
+
+	err = validator.Validate(Batch{Size: 2, Version: "1.0"})
+	if err == nil || validationMessage(err) != "value must start with v" {
+		t.Errorf("Expected custom 'prefix' rule to receive its param, but got: %v", err)
+	}
+
+	if err := New().Validate(Batch{Size: 3, Version: "1.0"}); err != nil {
+		t.Errorf("Expected unregistered rules to be ignored, but got: %s", err)
+	}
+}
+
+type Site struct {
+	Website string `validate:"url"`
+}
+
+func TestURLValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Site{Website: "https://example.com/foo"}); err != nil {
+		t.Errorf("Expected absolute URL to pass, but got: %s", err)
+	}
+
+	for _, website := range []string{"not a url", "/relative/path"} {
+		err := validator.Validate(Site{Website: website})
+		if err == nil {
+			t.Errorf("Expected %q to fail url, but got no error", website)
+		} else if validationMessage(err) != "invalid URL format" {
+			t.Errorf("Unexpected error for %q: %s", website, err)
+		}
+	}
+
+	validator.WithCustomErrors(CustomErrors{
+		"Website": {
+			"url": "Website must be a full URL",
+		},
+	})
+	err := validator.Validate(Site{Website: "/relative/path"})
+	if err == nil || err.Error() != "Field 'Website' validation failed: Website must be a full URL" {
+		t.Errorf("Expected custom url error, but got: %v", err)
+	}
+}
+
+type Homepage struct {
+	Link   string `validate:"url"`
+	Source string `validate:"required,url"`
+}
+
+func TestURLSchemeAndEmptyValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Homepage{Link: "https://example.com", Source: "http://example.com"}); err != nil {
+		t.Errorf("Expected http(s) URLs to pass, but got: %s", err)
+	}
+
+	if err := validator.Validate(Homepage{Link: "", Source: "https://example.com"}); err != nil {
+		t.Errorf("Expected empty optional URL to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Homepage{Link: "https://example.com", Source: ""})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Message != "field is required" {
+		t.Errorf("Expected required error for empty URL, but got: %v", err)
+	}
+
+	for _, link := range []string{"example.com", "ftp://x"} {
+		err := validator.Validate(Homepage{Link: link, Source: "https://example.com"})
+		if err == nil || validationMessage(err) != "invalid URL format" {
+			t.Errorf("Expected %q to fail url, but got: %v", link, err)
+		}
+	}
+}
+
+type Signup struct {
+	Email string `validate:"required,company_email"`
+	Age   int    `validate:"adult"`
+}
+
+func TestCombine(t *testing.T) {
+	base := New().RegisterRule("company_email", func(fv reflect.Value, param string) error {
+		if !strings.HasSuffix(fv.String(), "@example.com") {
+			return fmt.Errorf("email must belong to example.com")
+		}
+		return nil
+	})
+	extra := New().RegisterRule("adult", func(fv reflect.Value, param string) error {
+		if fv.Int() < 18 {
+			return fmt.Errorf("must be an adult")
+		}
+		return nil
+	})
+	combined := Combine(base, extra)
+
+	if err := combined.Validate(Signup{Email: "jane@example.com", Age: 30}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := combined.Validate(Signup{Email: "jane@other.org", Age: 16})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, but got: %v", err)
+	}
+	if len(errs) != 2 || validationMessage(errs[0]) != "email must belong to example.com" || validationMessage(errs[1]) != "must be an adult" {
+		t.Errorf("Expected both base and extra errors, but got: %s", errs)
+	}
+
+	// Both validators fail "required" identically; the duplicate is dropped.
+	err = combined.Validate(Signup{Email: "", Age: 30})
+	errs, ok = err.(ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Errorf("Expected a single deduplicated error, but got: %v", err)
+	}
+}
+
+func TestCombineOverlappingFailures(t *testing.T) {
+	type Applicant struct {
+		Email string `validate:"required,email"`
+		Age   int    `validate:"adult"`
+	}
+	base := New()
+	extra := New().RegisterRule("adult", func(fv reflect.Value, param string) error {
+		if fv.Int() < 18 {
+			return fmt.Errorf("must be an adult")
+		}
+		return nil
+	})
+
+	err := Combine(base, extra).Validate(Applicant{Email: "", Age: 16})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Expected required and adult errors, but got: %v", err)
+	}
+	if validationMessage(errs[0]) != "field is required" || validationMessage(errs[1]) != "must be an adult" {
+		t.Errorf("Unexpected errors: %s", errs)
+	}
+
+	err = Combine(base, extra).ValidatePaths(Applicant{Email: "", Age: 16}, "Email", "Age")
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("Expected both selected fields to be reported, but got: %v", err)
+	}
+}
+
+func TestCombineDedupesByFieldAndRule(t *testing.T) {
+	type Account struct {
+		Email string `validate:"required"`
+	}
+	base := New().WithCustomErrors(CustomErrors{"Email": {"required": "Email is required"}})
+	extra := New().WithCustomErrors(CustomErrors{"Email": {"required": "Please enter an email"}})
+
+	err := Combine(base, extra).ValidateAll(Account{})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || validationMessage(errs[0]) != "Email is required" {
+		t.Errorf("Expected one required error from the first validator, but got: %v", err)
+	}
+}
+
+type Contact struct {
+	Email string `validate:"canonical=email"`
+}
+
+func TestCanonicalValidation(t *testing.T) {
+	validator := New().RegisterNormalizer("email", func(s string) string {
+		local, domain, ok := strings.Cut(s, "@")
+		if !ok {
+			return s
+		}
+		return local + "@" + strings.ToLower(domain)
+	})
+
+	if err := validator.Validate(Contact{Email: "John.Doe@example.com"}); err != nil {
+		t.Errorf("Expected canonical email to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Contact{Email: "John.Doe@Example.COM"})
+	if err == nil {
+		t.Errorf("Expected mixed-case domain to fail canonical=email, but got no error")
+	} else if validationMessage(err) != "value is not in canonical email form" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Ticket struct {
+	Status string `validate:"oneof=active inactive pending"`
+	Level  int    `validate:"oneof=1 2 3"`
+	Stage  string `validate:"oneof='in progress' done"`
+}
+
+func TestOneOfValidation(t *testing.T) {
+	validator := New()
+
+	ticket := Ticket{Status: "active", Level: 2, Stage: "in progress"}
+	if err := validator.Validate(ticket); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	ticket.Status = "archived"
+	err := validator.Validate(ticket)
+	if err == nil {
+		t.Errorf("Expected 'archived' to fail oneof, but got no error")
+	} else if validationMessage(err) != "value must be one of [active inactive pending]" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	ticket.Status = "pending"
+	ticket.Level = 4
+	err = validator.Validate(ticket)
+	if err == nil || validationMessage(err) != "value must be one of [1 2 3]" {
+		t.Errorf("Expected int oneof to fail, but got: %v", err)
+	}
+
+	ticket.Level = 3
+	ticket.Stage = "in"
+	if err := validator.Validate(ticket); err == nil {
+		t.Errorf("Expected partial quoted value to fail oneof, but got no error")
+	}
+}
+
+type Resource struct {
+	ID      string `validate:"uuid"`
+	TraceID string `validate:"uuid4"`
+}
+
+func TestUUIDValidation(t *testing.T) {
+	validator := New()
+
+	resource := Resource{
+		ID:      "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		TraceID: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	}
+	if err := validator.Validate(resource); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	resource.ID = "6ba7b810-9dad-11d1-80b4"
+	err := validator.Validate(resource)
+	if err == nil {
+		t.Errorf("Expected malformed UUID to fail, but got no error")
+	} else if validationMessage(err) != "invalid UUID format" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	resource.ID = ""
+	if err := validator.Validate(resource); err == nil {
+		t.Errorf("Expected empty UUID to fail, but got no error")
+	}
+
+	resource.ID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	resource.TraceID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	if err := validator.Validate(resource); err == nil {
+		t.Errorf("Expected version 1 UUID to fail uuid4, but got no error")
+	}
+}
+
+func TestUUID4VariantAndCustomErrors(t *testing.T) {
+	validator := New()
+
+	for _, traceID := range []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"F47AC10B-58CC-4372-B567-0E02B2C3D479",
+	} {
+		resource := Resource{ID: traceID, TraceID: traceID}
+		if err := validator.Validate(resource); err != nil {
+			t.Errorf("Expected %q to pass uuid4, but got: %s", traceID, err)
+		}
+	}
+
+	resource := Resource{
+		ID:      "f47ac10b-58cc-4372-c567-0e02b2c3d479",
+		TraceID: "f47ac10b-58cc-4372-c567-0e02b2c3d479",
+	}
+	err := validator.Validate(resource)
+	if err == nil || validationMessage(err) != "invalid UUID format" {
+		t.Errorf("Expected invalid variant to fail uuid4, but got: %v", err)
+	}
+
+	validator.WithCustomErrors(CustomErrors{
+		"TraceID": {
+			"uuid": "Trace ID must be a version 4 UUID",
+		},
+	})
+	err = validator.Validate(resource)
+	if err == nil || err.Error() != "Field 'TraceID' validation failed: Trace ID must be a version 4 UUID" {
+		t.Errorf("Expected custom uuid error, but got: %v", err)
+	}
+}
+
+type Frame struct {
+	Flags   uint8
+	Options []string `validate:"lenmatchescount=Flags"`
+}
+
+func TestLenMatchesCountValidation(t *testing.T) {
+	validator := New()
+
+	frame := Frame{Flags: 0b1010, Options: []string{"compress", "encrypt"}}
+	if err := validator.Validate(frame); err != nil {
+		t.Errorf("Expected matching count to pass, but got: %s", err)
+	}
+
+	frame.Flags = 0b1011
+	err := validator.Validate(frame)
+	if err == nil {
+		t.Errorf("Expected mismatching count to fail, but got no error")
+	} else if validationMessage(err) != "length must equal number of set flags" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Playlist struct {
+	TrackIDs []int `validate:"min=1,unique"`
+}
+
+func TestUniqueValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Playlist{TrackIDs: []int{1, 2, 3}}); err != nil {
+		t.Errorf("Expected distinct values to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Playlist{TrackIDs: []int{7, 3, 5, 9, 3}})
+	if err == nil {
+		t.Errorf("Expected duplicate value to fail unique, but got no error")
+	} else if validationMessage(err) != "duplicate value at indices 1 and 4" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Playlist{}); err == nil {
+		t.Errorf("Expected empty slice to fail min=1, but got no error")
+	}
+}
+
+func TestUniqueUnhashableElements(t *testing.T) {
+	type Mixed struct {
+		Values []interface{} `validate:"unique"`
+	}
+	validator := New()
+
+	if err := validator.Validate(Mixed{Values: []interface{}{[]int{1}, []int{2}, 1, "a"}}); err != nil {
+		t.Errorf("Expected distinct unhashable values to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Mixed{Values: []interface{}{1, []int{1}, "a", []int{1}}})
+	if err == nil {
+		t.Errorf("Expected duplicate unhashable value to fail unique, but got no error")
+	} else if validationMessage(err) != "duplicate value at indices 1 and 3" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Page struct {
+	Slug string `validate:"required,regex=^[a-z0-9-]+$"`
+	Code string `validate:"regex=^[A-Z]{2\\,3}$"`
+}
+
+func TestRegexValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Page{Slug: "my-page-1", Code: "ABC"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(Page{Slug: "My Page", Code: "AB"})
+	if err == nil {
+		t.Errorf("Expected slug mismatch to fail, but got no error")
+	} else if validationMessage(err) != "value does not match pattern ^[a-z0-9-]+$" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// The escaped comma inside {2\,3} must not split the rule.
+	if err := validator.Validate(Page{Slug: "ok", Code: "ABCD"}); err == nil {
+		t.Errorf("Expected code with 4 letters to fail, but got no error")
+	}
+
+	type Tagged struct {
+		Handle string `validate:"required,regex=^[a-z]+$,max=5"`
+		Zip    string `validate:"regex='^[0-9]{5,6}$',required"`
+	}
+	err = validator.Validate(Tagged{Handle: "abcdefg", Zip: "12345"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Handle" || validationErr.Rule != "max" {
+		t.Errorf("Expected rules after regex= to still apply, but got: %v", err)
+	}
+	if err := validator.Validate(Tagged{Handle: "abc", Zip: "123456"}); err != nil {
+		t.Errorf("Expected quoted pattern to match, but got: %s", err)
+	}
+	err = validator.Validate(Tagged{Handle: "abc", Zip: ""})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Zip" || validationErr.Rule != "regex" {
+		t.Errorf("Expected empty zip to fail the pattern, but got: %v", err)
+	}
+
+	type broken struct {
+		Value string `validate:"regex=([a-z"`
+	}
+	err = validator.Validate(broken{Value: "abc"})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for invalid pattern, but got: %v", err)
+	}
+}
+
+type credentials struct {
+	username string `validate:"required,min=3"`
+	password string `validate:"min=8"`
+	Label    string `validate:"max=10"`
+}
+
+func TestRegisterAccessor(t *testing.T) {
+	validator := New().RegisterAccessor(credentials{}, func(v interface{}) map[string]interface{} {
+		c := v.(credentials)
+		return map[string]interface{}{
+			"username": c.username,
+			"password": c.password,
+		}
+	})
+
+	if err := validator.Validate(credentials{username: "jdoe", password: "s3cretpass"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(&credentials{username: "", password: "s3cretpass"})
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "username" {
+		t.Errorf("Expected required error on unexported 'username', but got: %v", err)
+	}
+
+	err = validator.Validate(credentials{username: "jdoe", password: "short"})
+	if err == nil || validationMessage(err) != "length is below minimum of 8" {
+		t.Errorf("Expected min error on unexported 'password', but got: %v", err)
+	}
+
+	if err := New().Validate(credentials{username: ""}); err != nil {
+		t.Errorf("Expected unexported fields to be skipped without an accessor, but got: %s", err)
+	}
+}
+
+type Inventory struct {
+	Count  string `validate:"parseuint8"`
+	Offset string `validate:"parseint16"`
+}
+
+func TestParseIntValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Inventory{Count: "255", Offset: "-32768"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(Inventory{Count: "256", Offset: "0"})
+	if err == nil {
+		t.Errorf("Expected 256 to overflow uint8, but got no error")
+	} else if validationMessage(err) != "value must be a valid uint8" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Inventory{Count: "-1", Offset: "0"}); err == nil {
+		t.Errorf("Expected negative value to fail parseuint8, but got no error")
+	}
+
+	err = validator.Validate(Inventory{Count: "1", Offset: "40000"})
+	if err == nil || validationMessage(err) != "value must be a valid int16" {
+		t.Errorf("Expected 40000 to overflow int16, but got: %v", err)
+	}
+}
+
+type Shift struct {
+	Date time.Time `validate:"weekday=Mon Tue Wed Thu Fri"`
+}
+
+func TestWeekdayValidation(t *testing.T) {
+	validator := New()
+
+	wednesday := time.Date(2024, time.May, 15, 9, 0, 0, 0, time.UTC)
+	if err := validator.Validate(Shift{Date: wednesday}); err != nil {
+		t.Errorf("Expected Wednesday to pass, but got: %s", err)
+	}
+
+	saturday := time.Date(2024, time.May, 18, 9, 0, 0, 0, time.UTC)
+	err := validator.Validate(Shift{Date: saturday})
+	if err == nil {
+		t.Errorf("Expected Saturday to fail, but got no error")
+	} else if validationMessage(err) != "date must fall on an allowed weekday" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+type Registration struct {
+	Email    string `validate:"required,email"`
+	Nickname string `validate:"max=8"`
+	Bio      string `validate:"len=10"`
+}
+
+func TestValidateAll(t *testing.T) {
+	err := New().ValidateAll(Registration{Email: "", Nickname: "far too long", Bio: "1234567890"})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, but got: %v", err)
+	}
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 errors, but got: %s", errs)
+	}
+
+	if err := New().ValidateAll(Registration{Email: "a@example.com", Bio: "1234567890"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+}
+
+func TestSeverityFiltering(t *testing.T) {
+	validator := New().
+		WithRuleSeverity("max", SeverityWarning).
+		WithRuleSeverity("len", SeverityInfo)
+
+	err := validator.ValidateAll(Registration{Email: "invalid", Nickname: "far too long", Bio: "short"})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, but got: %v", err)
+	}
+
+	hard := errs.FilterBySeverity(SeverityError)
+	if len(hard) != 1 || validationMessage(hard[0]) != "invalid email format" {
+		t.Errorf("Expected only the email error to be hard, but got: %s", hard)
+	}
+
+	warnings := errs.FilterBySeverity(SeverityWarning)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected one warning, but got: %s", warnings)
+	}
+	if warning, ok := warnings[0].(*ValidationError); !ok || warning.Field != "Nickname" || warning.Severity != SeverityWarning {
+		t.Errorf("Expected a warning on 'Nickname', but got: %s", warnings[0])
+	}
+
+	info := errs.FilterBySeverity(SeverityInfo)
+	if len(info) != 1 || info[0].(*ValidationError).Field != "Bio" {
+		t.Errorf("Expected one info advisory on 'Bio', but got: %s", info)
+	}
+}
+
+func TestParseValidationTagWithCommas(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{"required,max=10", []string{"required", "max=10"}},
+		{"required,oneof='red,green' blue", []string{"required", "oneof='red,green' blue"}},
+		{`oneof=a\,b c,min=1`, []string{"oneof=a,b c", "min=1"}},
+		{`min=1,regex=^[a-z]{2\,3}$,max=5`, []string{"min=1", "regex=^[a-z]{2,3}$", "max=5"}},
+	}
+
+	for _, tt := range tests {
+		got := parseValidationTag(tt.tag)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseValidationTag(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+type Paint struct {
+	Color string `validate:"required,oneof='red,green' blue"`
+}
+
+func TestOneOfWithCommaParameter(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Paint{Color: "red,green"}); err != nil {
+		t.Errorf("Expected quoted value with comma to pass, but got: %s", err)
+	}
+
+	if err := validator.Validate(Paint{Color: "red"}); err == nil {
+		t.Errorf("Expected 'red' to fail, but got no error")
+	}
+}
+
+func TestOneOfCustomErrors(t *testing.T) {
+	validator := New().WithCustomErrors(CustomErrors{
+		"Status": {
+			"oneof": "Status must be active, inactive or pending",
+		},
+		"Level": {
+			"oneof": "Level must be between 1 and 3",
+		},
+	})
+
+	err := validator.Validate(Ticket{Status: "archived", Level: 1, Stage: "done"})
+	if err == nil || err.Error() != "Field 'Status' validation failed: Status must be active, inactive or pending" {
+		t.Errorf("Expected custom oneof error for string field, but got: %v", err)
+	}
+
+	err = validator.Validate(Ticket{Status: "active", Level: 7, Stage: "done"})
+	if err == nil || err.Error() != "Field 'Level' validation failed: Level must be between 1 and 3" {
+		t.Errorf("Expected custom oneof error for int field, but got: %v", err)
+	}
+}
+
+type Invoice struct {
+	IssuedOn string `validate:"datetime_any=2006-01-02|02/01/2006|January 2\\, 2006"`
+}
+
+func TestDatetimeAnyValidation(t *testing.T) {
+	validator := New()
+
+	for _, issuedOn := range []string{"2024-03-15", "15/03/2024", "March 15, 2024"} {
+		if err := validator.Validate(Invoice{IssuedOn: issuedOn}); err != nil {
+			t.Errorf("Expected %q to match an accepted layout, but got: %s", issuedOn, err)
+		}
+	}
+
+	err := validator.Validate(Invoice{IssuedOn: "15.03.2024"})
+	if err == nil {
+		t.Errorf("Expected dotted date to fail, but got no error")
+	} else if validationMessage(err) != "value does not match any accepted date format" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func BenchmarkValidateEmail(b *testing.B) {
+	validator := New()
+	profile := Profile{Email: "john.doe@example.com"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := validator.Validate(profile); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type Buffer struct {
+	Size int `validate:"powerof2"`
+}
+
+func TestPowerOf2Validation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Buffer{Size: 16}); err != nil {
+		t.Errorf("Expected 16 to pass powerof2, but got: %s", err)
+	}
+
+	for _, size := range []int{24, 0, -8} {
+		err := validator.Validate(Buffer{Size: size})
+		if err == nil {
+			t.Errorf("Expected %d to fail powerof2, but got no error", size)
+		} else if validationMessage(err) != "value must be a power of two" {
+			t.Errorf("Unexpected error for %d: %s", size, err)
+		}
+	}
+}
+
+type Caller struct {
+	Phone string `validate:"regex=phone"`
+}
+
+func TestRegisterRegex(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterRegex("phone", `^\+[1-9][0-9]{7,14}$`); err != nil {
+		t.Fatalf("Expected pattern to compile, but got: %s", err)
+	}
+
+	if err := validator.Validate(Caller{Phone: "+14155552671"}); err != nil {
+		t.Errorf("Expected matching phone to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Caller{Phone: "555-2671"})
+	if err == nil {
+		t.Errorf("Expected non-matching phone to fail, but got no error")
+	} else if validationMessage(err) != "value does not match pattern phone" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Caller{Phone: "my phone"}); err == nil {
+		t.Errorf("Expected regex=phone to use the registered pattern, not a substring match, but got no error")
+	}
+
+	if err := validator.RegisterRegex("broken", "(["); err == nil {
+		t.Errorf("Expected invalid pattern to fail registration, but got no error")
+	}
+
+	type Typo struct {
+		Phone string `validate:"regexname=phnoe"`
+	}
+	err = validator.Validate(Typo{Phone: "phnoe"})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), `no regex registered under "phnoe"`) {
+		t.Errorf("Expected setup error for unregistered regex name, but got: %v", err)
+	}
+
+	type Strict struct {
+		Phone string `validate:"regexname=phone"`
+	}
+	if err := validator.Validate(Strict{Phone: "+14155552671"}); err != nil {
+		t.Errorf("Expected regexname= to use the registered pattern, but got: %s", err)
+	}
+
+	type Inline struct {
+		Phone string `validate:"regex=^[0-9-]+$"`
+	}
+	if err := validator.Validate(Inline{Phone: "555-2671"}); err != nil {
+		t.Errorf("Expected unregistered regex= parameter to match inline, but got: %s", err)
+	}
+}
+
+type Reference struct {
+	Ref string `validate:"jsonpointer"`
+}
+
+func TestJSONPointerValidation(t *testing.T) {
+	validator := New()
+
+	for _, ref := range []string{"/a/b/0", "", "/a~1b/m~0n"} {
+		if err := validator.Validate(Reference{Ref: ref}); err != nil {
+			t.Errorf("Expected %q to be a valid JSON pointer, but got: %s", ref, err)
+		}
+	}
+
+	for _, ref := range []string{"a/b", "/a/~2"} {
+		err := validator.Validate(Reference{Ref: ref})
+		if err == nil {
+			t.Errorf("Expected %q to fail jsonpointer, but got no error", ref)
+		} else if validationMessage(err) != "invalid JSON pointer" {
+			t.Errorf("Unexpected error for %q: %s", ref, err)
+		}
+	}
+}
+
+type Channel struct {
+	Type  string `validate:"oneof=email phone"`
+	Value string `validate:"required,switchon=Type"`
+}
+
+func TestSwitchOnValidation(t *testing.T) {
+	validator := New().RegisterSwitch("Value", map[string]string{
+		"email": "email",
+		"phone": `regex=^\+[1-9][0-9]{7\,14}$`,
+	})
+
+	if err := validator.Validate(Channel{Type: "email", Value: "jane@example.com"}); err != nil {
+		t.Errorf("Expected email branch to pass, but got: %s", err)
+	}
+	if err := validator.Validate(Channel{Type: "phone", Value: "+14155552671"}); err != nil {
+		t.Errorf("Expected phone branch to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Channel{Type: "email", Value: "+14155552671"})
+	if err == nil || validationMessage(err) != "invalid email format" {
+		t.Errorf("Expected phone number to fail the email branch, but got: %v", err)
+	}
+
+	err = validator.Validate(Channel{Type: "phone", Value: "jane@example.com"})
+	if err == nil || !strings.HasPrefix(validationMessage(err), "value does not match pattern") {
+		t.Errorf("Expected email to fail the phone branch, but got: %v", err)
+	}
+}
+
+func TestSwitchOnWithJSONFieldNames(t *testing.T) {
+	type Contact struct {
+		Type  string `json:"type" validate:"oneof=email phone"`
+		Value string `json:"value" validate:"required,switchon=Type"`
+	}
+	cases := map[string]string{"email": "email"}
+
+	err := New().UseJSONFieldNames().RegisterSwitch("Value", cases).Validate(Contact{Type: "email", Value: "not-an-email"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "value" || validationErr.Rule != "email" {
+		t.Errorf("Expected switch to apply under JSON field names, but got: %v", err)
+	}
+
+	upper := func(field reflect.StructField) string { return strings.ToUpper(field.Name) }
+	err = New().WithFieldNameFunc(upper).RegisterSwitch("Value", cases).Validate(Contact{Type: "email", Value: "not-an-email"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "VALUE" || validationErr.Rule != "email" {
+		t.Errorf("Expected switch to apply under a custom field name func, but got: %v", err)
+	}
+}
+
+func TestCustomErrorsForAnyRule(t *testing.T) {
+	var name string = "John Doe"
+	validator := New().WithCustomErrors(CustomErrors{
+		"Age": {
+			"min": "You must be at least 18 years old",
+		},
+		"Address": {
+			"len": "Address must be exactly 10 characters",
+		},
+	})
+
+	user := User{Name: &name, Email: "john@example.com", Age: 17, Address: "1234567890"}
+	err := validator.Validate(user)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, but got: %v", err)
+	}
+	if validationErr.Rule != "min" || validationErr.Message != "You must be at least 18 years old" {
+		t.Errorf("Expected custom min error, but got rule %q: %s", validationErr.Rule, validationErr)
+	}
+
+	user.Age = 30
+	user.Address = "Short"
+	err = validator.Validate(user)
+	validationErr, ok = err.(*ValidationError)
+	if !ok || validationErr.Rule != "len" || validationErr.Message != "Address must be exactly 10 characters" {
+		t.Errorf("Expected custom len error, but got: %v", err)
+	}
+}
+
+type Verification struct {
+	OTP       int    `validate:"digits=6"`
+	AccountNo string `validate:"digits_between=4\\,8"`
+}
+
+func TestDigitsValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Verification{OTP: 123456, AccountNo: "12345"}); err != nil {
+		t.Errorf("Expected no validation errors, but got: %s", err)
+	}
+
+	err := validator.Validate(Verification{OTP: 12345, AccountNo: "12345"})
+	if err == nil {
+		t.Errorf("Expected 12345 to fail digits=6, but got no error")
+	} else if validationMessage(err) != "value must have exactly 6 digits" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if err := validator.Validate(Verification{OTP: -123456, AccountNo: "12345"}); err != nil {
+		t.Errorf("Expected sign to be ignored, but got: %s", err)
+	}
+
+	err = validator.Validate(Verification{OTP: 123456, AccountNo: "123456789"})
+	if err == nil || validationMessage(err) != "value must have between 4 and 8 digits" {
+		t.Errorf("Expected 9 digits to fail digits_between, but got: %v", err)
+	}
+
+	if err := validator.Validate(Verification{OTP: 123456, AccountNo: "12a45"}); err == nil {
+		t.Errorf("Expected non-digit string to fail, but got no error")
+	}
+
+	type PinCodes struct {
+		Pin    int    `validate:"digits_between=4\\,8,required"`
+		Legacy string `validate:"digits_between='2,3'"`
+	}
+	err = validator.Validate(PinCodes{Pin: 7, Legacy: "12"})
+	if err == nil || validationMessage(err) != "value must have between 4 and 8 digits" {
+		t.Errorf("Expected 1-digit int to fail digits_between=4\\,8, but got: %v", err)
+	}
+	if err := validator.Validate(PinCodes{Pin: 1234, Legacy: "123"}); err != nil {
+		t.Errorf("Expected escaped and quoted bounds to pass, but got: %s", err)
+	}
+
+	type MissingBound struct {
+		Pin int `validate:"digits_between=4"`
+	}
+	err = validator.Validate(MissingBound{Pin: 7})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for digits_between without a maximum, but got: %v", err)
+	}
+}
+
+func TestValidateNonStruct(t *testing.T) {
+	validator := New()
+
+	var nilUser *User
+	tests := []struct {
+		input interface{}
+		want  string
+	}{
+		{42, "validate: expected struct, got int"},
+		{nilUser, "validate: expected struct, got nil *validator.User"},
+		{[]string{"a"}, "validate: expected struct, got slice"},
+		{nil, "validate: expected struct, got nil"},
+	}
+
+	for _, tt := range tests {
+		err := validator.Validate(tt.input)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Validate(%#v) = %v, want %q", tt.input, err, tt.want)
+		}
+
+		err = validator.ValidateAll(tt.input)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("ValidateAll(%#v) = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+type Cipher struct {
+	Nonce []byte   `validate:"min=12,max=16"`
+	IV    []byte   `validate:"len=12"`
+	Key   [32]byte `validate:"len=32"`
+}
+
+func TestByteSliceLengthValidation(t *testing.T) {
+	validator := New()
+	iv := make([]byte, 12)
+
+	if err := validator.Validate(Cipher{Nonce: make([]byte, 14), IV: iv}); err != nil {
+		t.Errorf("Expected nonce within range to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Cipher{Nonce: make([]byte, 8), IV: iv})
+	if err == nil || validationMessage(err) != "length must be at least 12" {
+		t.Errorf("Expected short nonce to fail, but got: %v", err)
+	}
+
+	err = validator.Validate(Cipher{Nonce: make([]byte, 20), IV: iv})
+	if err == nil || validationMessage(err) != "length must be at most 16" {
+		t.Errorf("Expected long nonce to fail, but got: %v", err)
+	}
+
+	err = validator.Validate(Cipher{Nonce: make([]byte, 12), IV: make([]byte, 11)})
+	if err == nil || validationMessage(err) != "length must be exactly 12" {
+		t.Errorf("Expected wrong IV length to fail len=12, but got: %v", err)
+	}
+}
+
+func TestValidationErrorRuleAndParam(t *testing.T) {
+	var name string = "John Doe"
+	user := User{Name: &name, Email: "john@example.com", Age: 101, Address: "1234567890"}
+
+	err := New().Validate(user)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, but got: %v", err)
+	}
+	if validationErr.Field != "Age" || validationErr.Rule != "max" || validationErr.Param != "100" {
+		t.Errorf("Expected Age/max/100, but got %s/%s/%s", validationErr.Field, validationErr.Rule, validationErr.Param)
+	}
+
+	user.Age = 30
+	user.Email = "invalid"
+	validationErr, ok = New().Validate(user).(*ValidationError)
+	if !ok || validationErr.Rule != "email" || validationErr.Param != "" {
+		t.Errorf("Expected email rule with no param, but got: %+v", validationErr)
+	}
+
+	user.Email = "john@example.com"
+	user.Name = nil
+	validationErr, ok = New().Validate(user).(*ValidationError)
+	if !ok || validationErr.Rule != "required" {
+		t.Errorf("Expected required rule for nil pointer, but got: %+v", validationErr)
+	}
+}
+
+type Config struct {
+	Path    string `validate:"filepath"`
+	DataDir string `validate:"abspath"`
+}
+
+func TestFilePathValidation(t *testing.T) {
+	validator := New()
+	absolute, _ := filepath.Abs("data")
+
+	if err := validator.Validate(Config{Path: "configs/app.yaml", DataDir: absolute}); err != nil {
+		t.Errorf("Expected relative and absolute paths to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Config{Path: "configs/app.yaml", DataDir: "data"})
+	if err == nil || validationMessage(err) != "invalid file path" {
+		t.Errorf("Expected relative path to fail abspath, but got: %v", err)
+	}
+
+	err = validator.Validate(Config{Path: "bad\x00name", DataDir: absolute})
+	if err == nil || validationMessage(err) != "invalid file path" {
+		t.Errorf("Expected path with null byte to fail, but got: %v", err)
+	}
+
+	if err := validator.Validate(Config{Path: "", DataDir: absolute}); err == nil {
+		t.Errorf("Expected empty path to fail, but got no error")
+	}
+}
+
+type Selection struct {
+	SelectedTags []string `validate:"subset=red green blue"`
+}
+
+func TestSubsetValidation(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Selection{SelectedTags: []string{"red", "blue"}}); err != nil {
+		t.Errorf("Expected allowed tags to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Selection{SelectedTags: []string{"red", "purple", "orange"}})
+	if err == nil {
+		t.Errorf("Expected 'purple' to fail subset, but got no error")
+	} else if validationMessage(err) != "value 'purple' is not in the allowed set" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func BenchmarkValidateInlineRegex(b *testing.B) {
+	validator := New()
+	page := Page{Slug: "my-page-1", Code: "ABC"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := validator.Validate(page); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type Envelope struct {
+	Payload interface{}
+}
+
+func TestPointerToPointerAndInterfaceValidation(t *testing.T) {
+	validator := New()
+	var name string = "John Doe"
+	user := &User{Name: &name, Email: "invalid", Age: 30, Address: "1234567890"}
+
+	err := validator.Validate(&user)
+	if err == nil || validationMessage(err) != "invalid email format" {
+		t.Errorf("Expected email error through **User, but got: %v", err)
+	}
+
+	var nilUser *User
+	err = validator.Validate(&nilUser)
+	if err == nil || err.Error() != "validate: expected struct, got nil *validator.User" {
+		t.Errorf("Expected descriptive error for nil inner pointer, but got: %v", err)
+	}
+
+	err = validator.Validate(Envelope{Payload: *user})
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "Payload.Email" {
+		t.Errorf("Expected error on 'Payload.Email' behind interface, but got: %v", err)
+	}
+
+	user.Email = "john@example.com"
+	if err := validator.Validate(Envelope{Payload: &user}); err != nil {
+		t.Errorf("Expected valid struct behind interface to pass, but got: %s", err)
+	}
+}
+
+type Settings struct {
+	Labels map[string]string `validate:"required"`
+}
+
+func TestRequiredMapNilVersusEmpty(t *testing.T) {
+	if err := New().Validate(Settings{Labels: map[string]string{}}); err == nil {
+		t.Errorf("Expected empty map to fail required by default, but got no error")
+	}
+
+	validator := New().DistinguishNilCollections()
+
+	if err := validator.Validate(Settings{Labels: map[string]string{}}); err != nil {
+		t.Errorf("Expected empty non-nil map to pass under the option, but got: %s", err)
+	}
+
+	err := validator.Validate(Settings{})
+	if err == nil || validationMessage(err) != "field is required" {
+		t.Errorf("Expected nil map to fail required, but got: %v", err)
+	}
+}
+
+func TestCachedFieldsReusesParsedRules(t *testing.T) {
+	typ := reflect.TypeOf(User{})
+
+	first := cachedFields(typ, "validate")
+	second := cachedFields(typ, "validate")
+
+	if len(first) != typ.NumField() {
+		t.Errorf("Expected %d cached fields, but got %d", typ.NumField(), len(first))
+	}
+	if len(first) > 0 && &first[0] != &second[0] {
+		t.Errorf("Expected cached field metadata to be reused between calls")
+	}
+
+	for _, meta := range first {
+		t.Logf("Field %s rules: %v", meta.name, meta.rules)
+	}
+}
+
+func BenchmarkValidateCold(b *testing.B) {
+	validator := New()
+	profile := Profile{Email: "john.doe@example.com"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		fieldCache.Delete(fieldCacheKey{typ: reflect.TypeOf(profile), tagName: "validate"})
+		validator.Validate(profile)
+	}
+}
+
+func BenchmarkValidateWarm(b *testing.B) {
+	validator := New()
+	profile := Profile{Email: "john.doe@example.com"}
+	validator.Validate(profile)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.Validate(profile)
+	}
+}
+
+type JSONSignup struct {
+	EmailAddress string  `json:"email_address,omitempty" validate:"email"`
+	Nickname     string  `json:"-" validate:"required"`
+	Country      string  `validate:"required"`
+	Contact      Profile `json:"contact"`
+}
+
+func TestUseJSONFieldNames(t *testing.T) {
+	validator := New().UseJSONFieldNames()
+
+	err := validator.ValidateAll(JSONSignup{EmailAddress: "invalid", Contact: Profile{Email: "invalid"}})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, but got: %v", err)
+	}
+
+	expected := []string{"email_address", "Nickname", "Country", "contact.Email"}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, but got: %s", len(expected), err)
+	}
+	for i, field := range expected {
+		if validationErr, ok := errs[i].(*ValidationError); !ok || validationErr.Field != field {
+			t.Errorf("Expected error %d to be for field '%s', but got: %s", i, field, errs[i])
+		}
+	}
+
+	validator.WithCustomErrors(CustomErrors{
+		"email_address": {
+			"email": "Please provide a valid email address",
+		},
+	})
+
+	err = validator.Validate(JSONSignup{EmailAddress: "invalid"})
+	if err == nil || validationMessage(err) != "Please provide a valid email address" {
+		t.Errorf("Expected custom error for JSON field name, but got: %v", err)
+	}
+
+	err = New().Validate(JSONSignup{EmailAddress: "invalid"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "EmailAddress" {
+		t.Errorf("Expected Go field name without the option, but got: %v", err)
+	}
+}
+
+type PriceTag struct {
+	Price float64 `validate:"increment=0.05"`
+}
+
+func TestIncrement(t *testing.T) {
+	validator := New()
+
+	for _, price := range []float64{0, 1.05, 0.1 + 0.2 + 0.05, 19.95, -2.5} {
+		if err := validator.Validate(PriceTag{Price: price}); err != nil {
+			t.Errorf("Expected %v to be a valid increment, but got: %s", price, err)
+		}
+	}
+
+	err := validator.Validate(PriceTag{Price: 1.07})
+	if err == nil || validationMessage(err) != "value must be a multiple of 0.05" {
+		t.Errorf("Expected increment error for 1.07, but got: %v", err)
+	}
+
+	type Invalid struct {
+		Price float64 `validate:"increment=abc"`
+	}
+	err = validator.Validate(Invalid{Price: 1})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected ErrInvalidRule for non-numeric step, but got: %v", err)
+	}
+}
+
+type Comment struct {
+	Author string `validate:"not_inset=banned"`
+}
+
+func TestLoadAllowlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned_words.txt")
+	if err := os.WriteFile(path, []byte("spam\n  scam \n\neggs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := New()
+	if err := validator.LoadAllowlist("banned", path); err != nil {
+		t.Fatalf("Expected list to load, but got: %s", err)
+	}
+
+	if err := validator.Validate(Comment{Author: "alice"}); err != nil {
+		t.Errorf("Expected 'alice' to be allowed, but got: %s", err)
+	}
+
+	for _, author := range []string{"spam", "scam", "eggs"} {
+		err := validator.Validate(Comment{Author: author})
+		if err == nil || validationMessage(err) != "value is not allowed" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", author, err)
+		}
+	}
+
+	if err := validator.LoadAllowlist("missing", filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("Expected error for a missing file, but got none")
+	}
+}
+
+type EnvVar struct {
+	Key string `validate:"envname"`
+}
+
+func TestEnvName(t *testing.T) {
+	validator := New()
+
+	for _, key := range []string{"MY_VAR", "_PRIVATE", "PATH2"} {
+		if err := validator.Validate(EnvVar{Key: key}); err != nil {
+			t.Errorf("Expected '%s' to be a valid name, but got: %s", key, err)
+		}
+	}
+
+	for _, key := range []string{"1VAR", "my-var", "lower", ""} {
+		err := validator.Validate(EnvVar{Key: key})
+		if err == nil || validationMessage(err) != "invalid environment variable name" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", key, err)
+		}
+	}
+}
+
+type TaggedRules struct {
+	Name  string `rules:"required"`
+	Email string `validate:"email"`
+}
+
+func TestWithTagName(t *testing.T) {
+	validator := New().WithTagName("rules")
+
+	err := validator.Validate(TaggedRules{Email: "invalid"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Name" || validationErr.Rule != "required" {
+		t.Errorf("Expected required error from the 'rules' tag, but got: %v", err)
+	}
+
+	if err := validator.Validate(TaggedRules{Name: "John", Email: "invalid"}); err != nil {
+		t.Errorf("Expected 'validate' tag to be ignored, but got: %s", err)
+	}
+
+	err = New().Validate(TaggedRules{Email: "invalid"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Email" {
+		t.Errorf("Expected default tag name to read 'validate', but got: %v", err)
+	}
+}
+
+type BindingForm struct {
+	Quantity int    `binding:"min=1,max=10"`
+	Code     string `binding:"required"`
+}
+
+func TestWithTagNameAppliesToAllEntryPoints(t *testing.T) {
+	validator := New().WithTagName("binding")
+
+	err := validator.ValidateAll(BindingForm{Quantity: 20})
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("Expected 2 errors from the 'binding' tag, but got: %v", err)
+	}
+
+	form := BindingForm{Quantity: 20, Code: "A1"}
+	fixed, err := validator.ValidateAndFix(&form)
+	if err != nil || form.Quantity != 10 || len(fixed) != 1 || fixed[0] != "Quantity" {
+		t.Errorf("Expected Quantity to be clamped using the 'binding' tag, but got: %v, %v, %d", fixed, err, form.Quantity)
+	}
+}
+
+type SignedMessage struct {
+	Payload  string
+	Checksum string `validate:"sha256of=Payload"`
+}
+
+type SignedBlob struct {
+	Payload  []byte
+	Checksum string `validate:"sha256of=Payload"`
+}
+
+func TestSHA256Of(t *testing.T) {
+	validator := New()
+	sum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	if err := validator.Validate(SignedMessage{Payload: "hello", Checksum: sum}); err != nil {
+		t.Errorf("Expected matching checksum to pass, but got: %s", err)
+	}
+
+	if err := validator.Validate(SignedBlob{Payload: []byte("hello"), Checksum: strings.ToUpper(sum)}); err != nil {
+		t.Errorf("Expected matching checksum of a byte slice to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(SignedMessage{Payload: "hello!", Checksum: sum})
+	if err == nil || validationMessage(err) != "checksum does not match payload" {
+		t.Errorf("Expected checksum mismatch error, but got: %v", err)
+	}
+}
+
+func TestCustomErrorsMatchOnRule(t *testing.T) {
+	var name string = "John"
+	validator := New().WithCustomErrors(CustomErrors{
+		"Email": {
+			"email": "Please provide a valid email",
+		},
+		"Age": {
+			"min": "You must be at least 18 years old",
+		},
+		"Address": {
+			"len": "Address must be exactly 10 characters",
+		},
+	})
+
+	err := validator.ValidateAll(User{Name: &name, Email: "invalidemailcom", Age: 17, Address: "Short"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("Expected 3 validation errors, but got: %v", err)
+	}
+
+	expected := []struct {
+		rule    Rule
+		message ErrorMsg
+	}{
+		{"email", "Please provide a valid email"},
+		{"min", "You must be at least 18 years old"},
+		{"len", "Address must be exactly 10 characters"},
+	}
+	for i, want := range expected {
+		validationErr, ok := errs[i].(*ValidationError)
+		if !ok || validationErr.Rule != want.rule || validationErr.Message != want.message {
+			t.Errorf("Expected %s error '%s', but got: %v", want.rule, want.message, errs[i])
+		}
+	}
+}
+
+type MultiSelect struct {
+	Tags []string `validate:"subsetof=allowedTags"`
+}
+
+func TestSubsetOf(t *testing.T) {
+	validator := New().RegisterValueSet("allowedTags", []string{"a", "b", "c"})
+
+	if err := validator.Validate(MultiSelect{Tags: []string{"a", "c"}}); err != nil {
+		t.Errorf("Expected allowed tags to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(MultiSelect{Tags: []string{"a", "x"}})
+	if err == nil || validationMessage(err) != "tags contains a value not in the allowed set" {
+		t.Errorf("Expected subsetof error, but got: %v", err)
+	}
+}
+
+type Customer struct {
+	FullName string  `validate:"required" yaml:"full_name"`
+	Billing  Billing `yaml:"billing"`
+}
+
+type Billing struct {
+	PostCode string `validate:"len=5" yaml:"post_code"`
+}
+
+func TestWithFieldNameFunc(t *testing.T) {
+	validator := New().WithFieldNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		return name
+	})
+
+	err := validator.ValidateAll(Customer{Billing: Billing{PostCode: "123"}})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Expected 2 validation errors, but got: %v", err)
+	}
+
+	for i, field := range []string{"full_name", "billing.post_code"} {
+		if validationErr, ok := errs[i].(*ValidationError); !ok || validationErr.Field != field {
+			t.Errorf("Expected error %d to be for field '%s', but got: %s", i, field, errs[i])
+		}
+	}
+
+	if name := JSONFieldName(reflect.TypeOf(JSONSignup{}).Field(0)); name != "email_address" {
+		t.Errorf("Expected JSONFieldName to strip options, but got: %s", name)
+	}
+	if name := JSONFieldName(reflect.TypeOf(JSONSignup{}).Field(1)); name != "Nickname" {
+		t.Errorf("Expected JSONFieldName to fall back to the Go name, but got: %s", name)
+	}
+}
+
+type PurchaseOrder struct {
+	LineItems map[string]float64
+	Discounts []int
+}
+
+func TestRegisterMapThreshold(t *testing.T) {
+	validator := New().
+		RegisterMapThreshold("LineItems", 1000, 3).
+		RegisterMapThreshold("Discounts", 50, 0)
+
+	order := PurchaseOrder{
+		LineItems: map[string]float64{"a": 1500, "b": 2000, "c": 999.99, "d": 1000, "e": 1200},
+		Discounts: []int{10, 50},
+	}
+	if err := validator.Validate(order); err != nil {
+		t.Errorf("Expected 3 items over the threshold to pass, but got: %s", err)
+	}
+
+	order.LineItems["f"] = 1000.01
+	err := validator.Validate(order)
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "LineItems" || validationErr.Rule != "mapthreshold" ||
+		validationErr.Message != "at most 3 values may exceed 1000, found 4" {
+		t.Errorf("Expected threshold error for LineItems, but got: %v", err)
+	}
+
+	err = validator.Validate(PurchaseOrder{Discounts: []int{10, 60}})
+	if err == nil || validationMessage(err) != "at most 0 values may exceed 50, found 1" {
+		t.Errorf("Expected threshold error for Discounts, but got: %v", err)
+	}
+}
+
+func TestRegisterRuleRejectsReservedNames(t *testing.T) {
+	noop := func(field reflect.Value, param string) error { return nil }
+
+	for _, name := range []string{"dive", "omitempty", "required", "keys", "endkeys"} {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Errorf("Expected RegisterRule(%q) to panic, but it did not", name)
+				} else if !strings.Contains(fmt.Sprint(r), "reserved") || !strings.Contains(fmt.Sprint(r), name) {
+					t.Errorf("Expected a descriptive panic for %q, but got: %v", name, r)
+				}
+			}()
+			New().RegisterRule(name, noop)
+		}()
+	}
+
+	validator := New().RegisterRule("myrule", noop)
+	if _, ok := validator.rules["myrule"]; !ok {
+		t.Errorf("Expected 'myrule' to be registered")
+	}
+}
+
+type Pricing struct {
+	Discount float64 `validate:"gte=0,lt=1"`
+	Stock    int     `validate:"lte=100"`
+}
+
+func TestGteLte(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Pricing{Discount: 0, Stock: 100}); err != nil {
+		t.Errorf("Expected inclusive bounds to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Pricing{Discount: 1, Stock: 0})
+	if err == nil || validationMessage(err) != "value must be less than 1" {
+		t.Errorf("Expected exclusive upper bound error, but got: %v", err)
+	}
+
+	err = validator.Validate(Pricing{Discount: -0.1})
+	if err == nil || validationMessage(err) != "value must be greater than or equal to 0" {
+		t.Errorf("Expected gte error, but got: %v", err)
+	}
+
+	err = validator.Validate(Pricing{Stock: 101})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Rule != "lte" || validationErr.Message != "value must be less than or equal to 100" {
+		t.Errorf("Expected lte error, but got: %v", err)
+	}
+
+	type Misused struct {
+		Name string `validate:"gte=3"`
+	}
+	err = validator.Validate(Misused{Name: "John"})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), "not applicable") {
+		t.Errorf("Expected 'not applicable' setup error for a string, but got: %v", err)
+	}
+}
+
+func TestCustomEmailErrorRegression(t *testing.T) {
+	var name string = "John"
+	validator := New().WithCustomErrors(CustomErrors{
+		"Email": {
+			"email": "Please provide a valid email",
+		},
+	})
+
+	err := validator.Validate(User{Name: &name, Email: "invalidemailcom", Age: 20, Address: "1234567890"})
+	if err == nil || validationMessage(err) != "Please provide a valid email" {
+		t.Errorf("Expected custom email message, but got: %v", err)
+	}
+	if err != nil && err.Error() != "Field 'Email' validation failed: Please provide a valid email" {
+		t.Errorf("Expected custom email message in error text, but got: %s", err)
+	}
+}
+
+type Status int
+
+const (
+	StatusDraft Status = iota
+	StatusActive
+	StatusArchived
+)
+
+type Document struct {
+	Status Status `validate:"enum"`
+}
+
+func TestRegisterEnum(t *testing.T) {
+	validator := New().RegisterEnum(Status(0), []int64{int64(StatusDraft), int64(StatusActive), int64(StatusArchived)})
+
+	if err := validator.Validate(Document{Status: StatusArchived}); err != nil {
+		t.Errorf("Expected registered enum value to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Document{Status: Status(7)})
+	if err == nil || validationMessage(err) != "invalid enum value" {
+		t.Errorf("Expected invalid enum error, but got: %v", err)
+	}
+}
+
+type ContactForm struct {
+	Name    string `validate:"required"`
+	Email   string `validate:"required"`
+	Message string `validate:"required,min=10"`
+}
+
+func TestWildcardCustomErrors(t *testing.T) {
+	validator := New().WithCustomErrors(CustomErrors{
+		"*": {
+			"required": "This field is required",
+		},
+		"Email": {
+			"required": "We need your email to reply",
+		},
+	})
+
+	err := validator.ValidateAll(ContactForm{Message: "too short"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("Expected 3 validation errors, but got: %v", err)
+	}
+
+	expected := []string{"This field is required", "We need your email to reply", "length is below minimum of 10"}
+	for i, message := range expected {
+		if validationMessage(errs[i]) != message {
+			t.Errorf("Expected error %d to be '%s', but got: %s", i, message, errs[i])
+		}
+	}
+}
+
+type Greeting struct {
+	Word string `validate:"len=3"`
+	Name string `validate:"min=4,max=4"`
+}
+
+func TestStringLengthCountsRunes(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Greeting{Word: "日本語", Name: "José"}); err != nil {
+		t.Errorf("Expected multibyte strings to be measured in runes, but got: %s", err)
+	}
+
+	err := validator.Validate(Greeting{Word: "日本", Name: "José"})
+	if err == nil || validationMessage(err) != "length must be exactly 3" {
+		t.Errorf("Expected len error for a 2-rune string, but got: %v", err)
+	}
+}
+
+type QueryParam struct {
+	Encoded string `validate:"urlencoded"`
+}
+
+func TestURLEncoded(t *testing.T) {
+	validator := New()
+
+	for _, value := range []string{"hello+world%21", "a%2Fb%3Fc%3D1", "plain"} {
+		if err := validator.Validate(QueryParam{Encoded: value}); err != nil {
+			t.Errorf("Expected '%s' to be valid URL-encoded text, but got: %s", value, err)
+		}
+	}
+
+	for _, value := range []string{"%zz", "hello world", "a/b"} {
+		err := validator.Validate(QueryParam{Encoded: value})
+		if err == nil || validationMessage(err) != "value is not valid URL-encoded text" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", value, err)
+		}
+	}
+}
+
+type Expense struct {
+	Description string
+	Amount      float64
+}
+
+func TestValidateBudget(t *testing.T) {
+	validator := New()
+	expenses := []Expense{
+		{"Hotel", 400},
+		{"Flights", 350.5},
+		{"Dinner", 300},
+		{"Taxi", 20},
+	}
+
+	if err := validator.ValidateBudget(expenses, "Amount", 1070.5); err != nil {
+		t.Errorf("Expected expenses within budget to pass, but got: %s", err)
+	}
+
+	err := validator.ValidateBudget(expenses, "Amount", 1000)
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "[2].Amount" || validationErr.Message != "running total 1050.5 exceeds budget of 1000 at index 2" {
+		t.Errorf("Expected budget to be breached at index 2, but got: %v", err)
+	}
+
+	if err := validator.ValidateBudget(expenses, "Missing", 1000); err == nil {
+		t.Errorf("Expected error for a missing field, but got none")
+	}
+}
+
+type Grid struct {
+	Matrix [][]int `validate:"rect,dive,min=1"`
+}
+
+func TestRect(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Grid{Matrix: [][]int{{1, 2}, {3, 4}, {5, 6}}}); err != nil {
+		t.Errorf("Expected rectangular matrix to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Grid{Matrix: [][]int{{1, 2}, {3, 4}, {5}}})
+	if err == nil || validationMessage(err) != "row 2 has length 1, expected 2" {
+		t.Errorf("Expected jagged matrix to fail at row 2, but got: %v", err)
+	}
+
+	err = validator.Validate(Grid{Matrix: [][]int{{}, {}}})
+	if err == nil || !strings.HasPrefix(err.Error(), "Field 'Matrix[0]'") {
+		t.Errorf("Expected dive to apply to rows after rect passes, but got: %v", err)
+	}
+}
+
+func TestCustomErrorPlaceholders(t *testing.T) {
+	name := strings.Repeat("a", 51)
+	validator := New().WithCustomErrors(CustomErrors{
+		"*": {
+			"max":      "{field} exceeds {param}",
+			"required": "{field} is required{param}",
+		},
+	})
+
+	err := validator.Validate(User{Name: &name, Email: "john.doe@example.com", Age: 20, Address: "1234567890"})
+	if err == nil || validationMessage(err) != "Name exceeds 50" {
+		t.Errorf("Expected templated max message, but got: %v", err)
+	}
+
+	err = validator.Validate(User{Age: 20, Address: "1234567890"})
+	if err == nil || validationMessage(err) != "Name is required" {
+		t.Errorf("Expected empty {param} for a rule without a parameter, but got: %v", err)
+	}
+}
+
+type Lineup struct {
+	Players []string       `validate:"len=3,dive,min=2"`
+	Scores  map[string]int `validate:"len=2"`
+	Items   []int          `validate:"min=1,max=10,dive,max=100"`
+}
+
+func TestCollectionLen(t *testing.T) {
+	validator := New()
+	valid := Lineup{
+		Players: []string{"Ann", "Bob", "Cy"},
+		Scores:  map[string]int{"Ann": 1, "Bob": 2},
+		Items:   []int{5, 100},
+	}
+
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Expected lineup to pass, but got: %s", err)
+	}
+
+	lineup := valid
+	lineup.Players = []string{"Ann", "Bob"}
+	err := validator.Validate(lineup)
+	if err == nil || validationMessage(err) != "must contain exactly 3 elements, got 2" {
+		t.Errorf("Expected slice len error, but got: %v", err)
+	}
+
+	lineup = valid
+	lineup.Scores = map[string]int{"Ann": 1}
+	err = validator.Validate(lineup)
+	if err == nil || validationMessage(err) != "must contain exactly 2 elements, got 1" {
+		t.Errorf("Expected map len error, but got: %v", err)
+	}
+
+	lineup = valid
+	lineup.Items = []int{101}
+	err = validator.Validate(lineup)
+	if err == nil || !strings.HasPrefix(err.Error(), "Field 'Items[0]'") {
+		t.Errorf("Expected max after dive to bound element values, but got: %v", err)
+	}
+}
+
+type Checkout struct {
+	HasDiscount  bool
+	DiscountCode string `validate:"required_if=HasDiscount true"`
+}
+
+func TestRequiredIf(t *testing.T) {
+	validator := New()
+
+	err := validator.Validate(Checkout{HasDiscount: true})
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "DiscountCode" || validationErr.Rule != "required_if" || validationErr.Message != "field is required" {
+		t.Errorf("Expected required error when HasDiscount is true, but got: %v", err)
+	}
+
+	if err := validator.Validate(Checkout{HasDiscount: true, DiscountCode: "SAVE10"}); err != nil {
+		t.Errorf("Expected set DiscountCode to pass, but got: %s", err)
+	}
+
+	if err := validator.Validate(Checkout{HasDiscount: false}); err != nil {
+		t.Errorf("Expected DiscountCode to be optional when HasDiscount is false, but got: %s", err)
+	}
+
+	type Broken struct {
+		Code string `validate:"required_if=Missing true"`
+	}
+	if err := validator.Validate(Broken{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for an unknown field, but got: %v", err)
+	}
+}
+
+type Agreement struct {
+	Terms   bool    `validate:"eq=true"`
+	Role    string  `validate:"ne=banned"`
+	Version int     `validate:"eq=2"`
+	Rate    float64 `validate:"ne=0"`
+}
+
+func TestEqNe(t *testing.T) {
+	validator := New()
+	valid := Agreement{Terms: true, Role: "member", Version: 2, Rate: 0.5}
+
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Expected agreement to pass, but got: %s", err)
+	}
+
+	agreement := valid
+	agreement.Terms = false
+	err := validator.Validate(agreement)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Rule != "eq" || validationErr.Message != "value must equal true" {
+		t.Errorf("Expected eq error, but got: %v", err)
+	}
+
+	agreement = valid
+	agreement.Role = "banned"
+	err = validator.Validate(agreement)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Rule != "ne" || validationErr.Message != "value must not equal banned" {
+		t.Errorf("Expected ne error, but got: %v", err)
+	}
+
+	err = validator.WithCustomErrors(CustomErrors{
+		"Role": {"ne": "This role is not allowed"},
+	}).Validate(agreement)
+	if err == nil || validationMessage(err) != "This role is not allowed" {
+		t.Errorf("Expected custom ne message, but got: %v", err)
+	}
+
+	type Mismatched struct {
+		Count int `validate:"eq=true"`
+	}
+	err = New().Validate(Mismatched{Count: 1})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), "eq=true cannot be compared with a int field") {
+		t.Errorf("Expected setup error for a type mismatch, but got: %v", err)
+	}
+}
+
+type Credentials struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"min=8,regex=[0-9]"`
+}
+
+func TestCombineFieldErrors(t *testing.T) {
+	validator := New().CombineFieldErrors().WithCustomErrors(CustomErrors{
+		"Email": {"email": "must be a valid email"},
+	})
+
+	err := validator.ValidateAll(Credentials{Password: "short"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Expected one combined error per field, but got: %v", err)
+	}
+
+	if errs[0].Error() != "Field 'Email' validation failed: field is required; must be a valid email" {
+		t.Errorf("Expected joined message for Email, but got: %s", errs[0])
+	}
+	if validationErr, ok := errs[0].(*ValidationError); !ok || validationErr.Rule != "required,email" {
+		t.Errorf("Expected combined rule names, but got: %v", errs[0])
+	}
+
+	err = validator.Validate(Credentials{Email: "john.doe@example.com", Password: "longenough"})
+	if err == nil || validationMessage(err) != "value does not match pattern [0-9]" {
+		t.Errorf("Expected a single failure to be reported as is, but got: %v", err)
+	}
+
+	err = New().Validate(Credentials{Password: "password1"})
+	if err == nil || validationMessage(err) != "field is required" {
+		t.Errorf("Expected only the first failure without the option, but got: %v", err)
+	}
+}
+
+type Base32Token struct {
+	Token string `validate:"base32"`
+	HexID string `validate:"base32hex"`
+}
+
+func TestBase32(t *testing.T) {
+	validator := New()
+
+	for _, token := range []Base32Token{
+		{Token: "MZXW6YTBOI======", HexID: "CPNMUOJ1E8======"},
+		{Token: "MFRGG===", HexID: ""},
+		{},
+	} {
+		if err := validator.Validate(token); err != nil {
+			t.Errorf("Expected %+v to be valid base32, but got: %s", token, err)
+		}
+	}
+
+	err := validator.Validate(Base32Token{Token: "MZXW6YTBOI"})
+	if err == nil || validationMessage(err) != "value must be valid base32" {
+		t.Errorf("Expected error for unpadded base32, but got: %v", err)
+	}
+
+	err = validator.Validate(Base32Token{Token: "not base32!"})
+	if err == nil || validationMessage(err) != "value must be valid base32" {
+		t.Errorf("Expected error for invalid characters, but got: %v", err)
+	}
+
+	err = validator.Validate(Base32Token{HexID: "MZXW6YTBOI======"})
+	if err == nil || validationMessage(err) != "value must be valid base32hex" {
+		t.Errorf("Expected error for standard alphabet in base32hex, but got: %v", err)
+	}
+}
+
+type Generated struct {
+	Name string `validate:"goidentifier"`
+}
+
+func TestGoIdentifier(t *testing.T) {
+	validator := New()
+
+	for _, name := range []string{"myVar", "_private", "Ünicode2", "x"} {
+		if err := validator.Validate(Generated{Name: name}); err != nil {
+			t.Errorf("Expected '%s' to be a valid identifier, but got: %s", name, err)
+		}
+	}
+
+	for _, name := range []string{"123abc", "func", "my-var", ""} {
+		err := validator.Validate(Generated{Name: name})
+		if err == nil || validationMessage(err) != "value is not a valid Go identifier" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", name, err)
+		}
+	}
+}
+
+type Device struct {
+	IMEI string `validate:"luhn"`
+}
+
+func TestLuhn(t *testing.T) {
+	validator := New()
+
+	for _, imei := range []string{"490154203237518", "4111 1111 1111 1111", "0"} {
+		if err := validator.Validate(Device{IMEI: imei}); err != nil {
+			t.Errorf("Expected '%s' to pass the Luhn check, but got: %s", imei, err)
+		}
+	}
+
+	for _, imei := range []string{"490154203237519", "4111-1111-1111-1111", ""} {
+		err := validator.Validate(Device{IMEI: imei})
+		if err == nil || validationMessage(err) != "value fails Luhn check" {
+			t.Errorf("Expected '%s' to fail the Luhn check, but got: %v", imei, err)
+		}
+	}
+}
+
+type PasswordChange struct {
+	Password        string `validate:"required"`
+	PasswordConfirm string `validate:"eqfield=Password"`
+	OldPassword     string `validate:"nefield=Password"`
+}
+
+type Bounds struct {
+	Low  int     `validate:"ltfield=High"`
+	High int     `validate:"gtfield=Low"`
+	Cap  float64 `validate:"gtfield=High"`
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(PasswordChange{Password: "secret1", PasswordConfirm: "secret1", OldPassword: "secret0"}); err != nil {
+		t.Errorf("Expected matching passwords to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(PasswordChange{Password: "secret1", PasswordConfirm: "secret2", OldPassword: "secret0"})
+	if err == nil || err.Error() != "Field 'PasswordConfirm' validation failed: value must equal field Password" {
+		t.Errorf("Expected eqfield error naming both fields, but got: %v", err)
+	}
+
+	err = validator.Validate(PasswordChange{Password: "secret1", PasswordConfirm: "secret1", OldPassword: "secret1"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Rule != "nefield" || validationErr.Param != "Password" {
+		t.Errorf("Expected nefield error, but got: %v", err)
+	}
+
+	if err := validator.Validate(Bounds{Low: 1, High: 5, Cap: 5.5}); err != nil {
+		t.Errorf("Expected ordered range to pass, but got: %s", err)
+	}
+
+	err = validator.Validate(Bounds{Low: 5, High: 5, Cap: 10})
+	if err == nil || validationMessage(err) != "value must be less than field High" {
+		t.Errorf("Expected ltfield error, but got: %v", err)
+	}
+
+	err = validator.Validate(Bounds{Low: 1, High: 5, Cap: 4.5})
+	if err == nil || err.Error() != "Field 'Cap' validation failed: value must be greater than field High" {
+		t.Errorf("Expected gtfield error across int and float, but got: %v", err)
+	}
+
+	type Broken struct {
+		Confirm string `validate:"eqfield=Missing"`
+	}
+	err = validator.Validate(Broken{})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), `"Missing"`) {
+		t.Errorf("Expected setup error for an unknown field, but got: %v", err)
+	}
+}
+
+type Stay struct {
+	StartDate time.Time
+	EndDate   time.Time `validate:"gtfield=StartDate"`
+	Guests    int
+	Rooms     int `validate:"ltfield=Guests"`
+}
+
+func TestOrderedCrossFieldTimes(t *testing.T) {
+	validator := New()
+	start := time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)
+
+	if err := validator.Validate(Stay{StartDate: start, EndDate: start.Add(48 * time.Hour), Guests: 3, Rooms: 2}); err != nil {
+		t.Errorf("Expected valid stay to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Stay{StartDate: start, EndDate: start, Guests: 3, Rooms: 2})
+	if err == nil || err.Error() != "Field 'EndDate' validation failed: value must be greater than field StartDate" {
+		t.Errorf("Expected gtfield error for equal times, but got: %v", err)
+	}
+
+	err = validator.Validate(Stay{StartDate: start, EndDate: start.Add(time.Hour), Guests: 2, Rooms: 2})
+	if err == nil || validationMessage(err) != "value must be less than field Guests" {
+		t.Errorf("Expected ltfield error for ints, but got: %v", err)
+	}
+
+	type Mismatched struct {
+		Start time.Time
+		Count int `validate:"gtfield=Start"`
+	}
+	err = validator.Validate(Mismatched{Start: start, Count: 1})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for mismatched types, but got: %v", err)
+	}
+}
+
+type Appointment struct {
+	Date    time.Time `validate:"weekday"`
+	Payment time.Time `validate:"businessday"`
+}
+
+func TestWeekdayAndBusinessDay(t *testing.T) {
+	monday := time.Date(2024, 12, 23, 9, 0, 0, 0, time.UTC)
+	christmas := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2024, 12, 22, 9, 0, 0, 0, time.UTC)
+
+	validator := New().RegisterHolidays(christmas)
+
+	if err := validator.Validate(Appointment{Date: monday, Payment: monday}); err != nil {
+		t.Errorf("Expected Monday to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Appointment{Date: sunday, Payment: monday})
+	if err == nil || validationMessage(err) != "date must be a weekday" {
+		t.Errorf("Expected Sunday to fail weekday, but got: %v", err)
+	}
+
+	err = validator.Validate(Appointment{Date: christmas, Payment: christmas.Add(15 * time.Hour)})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Payment" || validationErr.Message != "date must be a business day" {
+		t.Errorf("Expected holiday to fail businessday only, but got: %v", err)
+	}
+}
+
+type ShippingAddress struct {
+	Country  string
+	State    string  `validate:"required_if=Country US"`
+	Province *string `validate:"required_unless=Country US"`
+	Phone    string
+	Email    string `validate:"required_without=Phone"`
+}
+
+func TestConditionalRequired(t *testing.T) {
+	validator := New()
+	province := "Ontario"
+
+	if err := validator.Validate(ShippingAddress{Country: "US", State: "CA", Phone: "555-0100"}); err != nil {
+		t.Errorf("Expected US address with state and phone to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(ShippingAddress{Country: "US", Phone: "555-0100"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "State" || validationErr.Rule != "required_if" {
+		t.Errorf("Expected State to be required for US, but got: %v", err)
+	}
+
+	if err := validator.Validate(ShippingAddress{Country: "CA", Province: &province, Email: "a@example.com"}); err != nil {
+		t.Errorf("Expected non-US address with province and email to pass, but got: %s", err)
+	}
+
+	err = validator.Validate(ShippingAddress{Country: "CA", Email: "a@example.com"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Province" || validationErr.Rule != "required_unless" {
+		t.Errorf("Expected Province to be required outside the US, but got: %v", err)
+	}
+
+	err = validator.Validate(ShippingAddress{Country: "US", State: "CA"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Email" || validationErr.Rule != "required_without" {
+		t.Errorf("Expected Email to be required without Phone, but got: %v", err)
+	}
+
+	type Broken struct {
+		Email string `validate:"required_without=Missing"`
+	}
+	if err := validator.Validate(Broken{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for an unknown field, but got: %v", err)
+	}
+}
+
+type Request struct {
+	DateHeader string `validate:"today=2006-01-02"`
+}
+
+func TestToday(t *testing.T) {
+	validator := New().SetClock(func() time.Time {
+		return time.Date(2024, 3, 15, 23, 30, 0, 0, time.UTC)
+	})
+
+	if err := validator.Validate(Request{DateHeader: "2024-03-15"}); err != nil {
+		t.Errorf("Expected today's date to pass, but got: %s", err)
+	}
+
+	for _, header := range []string{"2024-03-14", "not a date"} {
+		err := validator.Validate(Request{DateHeader: header})
+		if err == nil || validationMessage(err) != "value must be today's date" {
+			t.Errorf("Expected '%s' to fail, but got: %v", header, err)
+		}
+	}
+}
+
+type LineItem struct {
+	SKU  string
+	Tags []string
+}
+
+type Basket struct {
+	Items []LineItem `validate:"uniquedeep"`
+}
+
+func TestUniqueDeep(t *testing.T) {
+	validator := New()
+
+	distinct := Basket{Items: []LineItem{
+		{SKU: "A", Tags: []string{"x"}},
+		{SKU: "A", Tags: []string{"y"}},
+		{SKU: "B", Tags: []string{"x"}},
+	}}
+	if err := validator.Validate(distinct); err != nil {
+		t.Errorf("Expected distinct items to pass, but got: %s", err)
+	}
+
+	duplicated := Basket{Items: []LineItem{
+		{SKU: "A", Tags: []string{"x"}},
+		{SKU: "B", Tags: []string{"x"}},
+		{SKU: "A", Tags: []string{"x"}},
+	}}
+	err := validator.Validate(duplicated)
+	if err == nil || validationMessage(err) != "slice contains duplicate elements" {
+		t.Errorf("Expected duplicate items to fail, but got: %v", err)
+	}
+}
+
+type Member struct {
+	Nickname string   `validate:"omitempty,min=3"`
+	Website  string   `validate:"omitempty,url"`
+	Referrer *string  `validate:"omitempty,email"`
+	Handle   string   `validate:"omitempty,required,min=3"`
+	Aliases  []string `validate:"dive,omitempty,min=2"`
+}
+
+func TestOmitEmpty(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Member{Handle: "john", Aliases: []string{"jd", ""}}); err != nil {
+		t.Errorf("Expected empty optional fields to skip their rules, but got: %s", err)
+	}
+
+	err := validator.Validate(Member{Nickname: "jo", Handle: "john"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Nickname" || validationErr.Rule != "min" {
+		t.Errorf("Expected min to apply to a non-empty Nickname, but got: %v", err)
+	}
+
+	referrer := "invalid"
+	err = validator.Validate(Member{Referrer: &referrer, Handle: "john"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Referrer" {
+		t.Errorf("Expected email to apply to a non-nil Referrer, but got: %v", err)
+	}
+
+	err = validator.Validate(Member{})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Handle" || validationErr.Rule != "required" {
+		t.Errorf("Expected required to win over omitempty, but got: %v", err)
+	}
+
+	err = validator.Validate(Member{Handle: "john", Aliases: []string{"j"}})
+	if err == nil || !strings.HasPrefix(err.Error(), "Field 'Aliases[0]'") {
+		t.Errorf("Expected omitempty after dive to apply per element, but got: %v", err)
+	}
+}
+
+func TestOmitEmptyZeroValues(t *testing.T) {
+	type Optional struct {
+		Count   uint      `validate:"omitempty,min=3"`
+		Total   int64     `validate:"omitempty,min=3"`
+		Score   float64   `validate:"omitempty,min=0.5"`
+		Enabled bool      `validate:"omitempty,eq=true"`
+		Since   time.Time `validate:"omitempty,weekday"`
+	}
+	validator := New()
+
+	if err := validator.Validate(Optional{}); err != nil {
+		t.Errorf("Expected zero values to skip omitempty rules, but got: %s", err)
+	}
+
+	err := validator.Validate(Optional{Count: 1})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Count" || validationErr.Rule != "min" {
+		t.Errorf("Expected min to apply to a non-zero uint, but got: %v", err)
+	}
+
+	type Signup struct {
+		Age int `validate:"omitempty,min=18"`
+	}
+	signup := Signup{}
+	fixed, err := validator.ValidateAndFix(&signup)
+	if err != nil || len(fixed) != 0 || signup.Age != 0 {
+		t.Errorf("Expected empty optional Age to be left alone, but got: %+v (fixed %v, err %v)", signup, fixed, err)
+	}
+
+	signup.Age = 12
+	if _, err := validator.ValidateAndFix(&signup); err != nil || signup.Age != 18 {
+		t.Errorf("Expected non-empty Age to be clamped to 18, but got: %+v (err %v)", signup, err)
+	}
+}
+
+type PathCustomer struct {
+	Name  string `validate:"required"`
+	Email string `validate:"email"`
+}
+
+type PathItem struct {
+	SKU   string `validate:"required"`
+	Price int    `validate:"min=1"`
+}
+
+type PathOrder struct {
+	Reference string `validate:"required"`
+	Customer  PathCustomer
+	Items     []PathItem
+}
+
+func TestValidatePaths(t *testing.T) {
+	validator := New()
+	order := PathOrder{
+		Customer: PathCustomer{Email: "john.doe@example.com"},
+		Items:    []PathItem{{SKU: "A1"}, {SKU: "B2"}},
+	}
+
+	if err := validator.ValidatePaths(order, "Customer.Email"); err != nil {
+		t.Errorf("Expected only Customer.Email to be validated, but got: %s", err)
+	}
+
+	order.Customer.Email = "invalid"
+	err := validator.ValidatePaths(order, "Customer.Email")
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Customer.Email" {
+		t.Errorf("Expected Customer.Email error, but got: %v", err)
+	}
+
+	if err := validator.ValidatePaths(order, "Items[*].SKU"); err != nil {
+		t.Errorf("Expected all SKUs to pass, but got: %s", err)
+	}
+
+	order.Items[1].SKU = ""
+	err = validator.ValidatePaths(order, "Items[*].SKU")
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Items[1].SKU" {
+		t.Errorf("Expected Items[1].SKU error, but got: %v", err)
+	}
+
+	err = validator.ValidatePaths(order, "Customer")
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Customer.Name" {
+		t.Errorf("Expected selecting Customer to validate its whole subtree, but got: %v", err)
+	}
+
+	if err := validator.Validate(order); err == nil {
+		t.Errorf("Expected full validation to still report errors")
+	}
+}
+
+type Gradebook struct {
+	Scores map[string]int    `validate:"dive,min=0,max=100"`
+	Labels map[string]string `validate:"dive,keys,min=2,endkeys,required"`
+}
+
+func TestMapDive(t *testing.T) {
+	validator := New()
+
+	valid := Gradebook{
+		Scores: map[string]int{"math": 90, "art": 0},
+		Labels: map[string]string{"en": "Hello", "de": "Hallo"},
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Expected valid gradebook to pass, but got: %s", err)
+	}
+
+	err := validator.Validate(Gradebook{Scores: map[string]int{"math": 120, "art": 50, "bio": 101}})
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Field != "Scores[bio]" || validationErr.Rule != "max" {
+		t.Errorf("Expected the first out-of-range value by key order, but got: %v", err)
+	}
+
+	err = validator.Validate(Gradebook{Labels: map[string]string{"english": "Hello", "x": "?"}})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Labels[x]" || validationErr.Rule != "min" {
+		t.Errorf("Expected key rule error for 'x', but got: %v", err)
+	}
+
+	err = validator.Validate(Gradebook{Labels: map[string]string{"en": ""}})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Labels[en]" || validationErr.Rule != "required" {
+		t.Errorf("Expected value rule error for 'en', but got: %v", err)
+	}
+
+	type Broken struct {
+		Labels map[string]string `validate:"dive,keys,min=2"`
+	}
+	if err := validator.Validate(Broken{Labels: map[string]string{"en": "Hello"}}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for keys without endkeys, but got: %v", err)
+	}
+}
+
+type AuditEntry struct {
+	CreatedAt time.Time `validate:"before=now"`
+	Effective time.Time `validate:"after=2024-01-01T00:00:00Z"`
+	Expires   time.Time `validate:"before=2030-01-01T00:00:00Z"`
+	Logged    string    `validate:"datetime=2006-01-02 15:04"`
+}
+
+func TestTimeRules(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	validator := New().SetClock(func() time.Time { return now })
+
+	valid := AuditEntry{
+		CreatedAt: now.Add(-time.Minute),
+		Effective: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Expires:   time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC),
+		Logged:    "2025-06-01 11:59",
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Expected valid entry to pass, but got: %s", err)
+	}
+
+	entry := valid
+	entry.CreatedAt = now.Add(time.Minute)
+	err := validator.Validate(entry)
+	if err == nil || validationMessage(err) != "time must be before now" {
+		t.Errorf("Expected before=now error, but got: %v", err)
+	}
+
+	entry = valid
+	entry.Effective = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = validator.Validate(entry)
+	if err == nil || validationMessage(err) != "time must be after 2024-01-01T00:00:00Z" {
+		t.Errorf("Expected after literal error, but got: %v", err)
+	}
+
+	entry = valid
+	entry.Expires = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = validator.Validate(entry)
+	if err == nil || validationMessage(err) != "time must be before 2030-01-01T00:00:00Z" {
+		t.Errorf("Expected before literal error, but got: %v", err)
+	}
+
+	entry = valid
+	entry.Logged = "01/06/2025 11:59"
+	err = validator.Validate(entry)
+	if err == nil || validationMessage(err) != "value must be a datetime in layout 2006-01-02 15:04" {
+		t.Errorf("Expected datetime layout error, but got: %v", err)
+	}
+
+	type Broken struct {
+		At time.Time `validate:"before=tomorrow"`
+	}
+	if err := validator.Validate(Broken{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for an invalid bound, but got: %v", err)
+	}
+}
+
+type BlogPost struct {
+	Slug string `validate:"slug"`
+}
+
+func TestSlug(t *testing.T) {
+	validator := New()
+
+	for _, slug := range []string{"my-post-1", "post", "2024"} {
+		if err := validator.Validate(BlogPost{Slug: slug}); err != nil {
+			t.Errorf("Expected '%s' to be a valid slug, but got: %s", slug, err)
+		}
+	}
+
+	for _, slug := range []string{"My_Post", "-bad-", "double--hyphen", ""} {
+		err := validator.Validate(BlogPost{Slug: slug})
+		if err == nil || validationMessage(err) != "invalid slug" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", slug, err)
+		}
+	}
+
+	err := validator.WithCustomErrors(CustomErrors{
+		"Slug": {"slug": "Use lowercase words separated by hyphens"},
+	}).Validate(BlogPost{Slug: "My_Post"})
+	if err == nil || validationMessage(err) != "Use lowercase words separated by hyphens" {
+		t.Errorf("Expected custom slug message, but got: %v", err)
+	}
+}
+
+type Snapshot struct {
+	ID       string  `validate:"required"`
+	Internal string  `validate:"-"`
+	Raw      Profile `validate:"-"`
+	Meta     Profile
+}
+
+func TestSkipMarker(t *testing.T) {
+	validator := New()
+
+	snapshot := Snapshot{ID: "s1", Raw: Profile{Email: "invalid"}, Meta: Profile{Email: "john.doe@example.com"}}
+	if err := validator.Validate(snapshot); err != nil {
+		t.Errorf("Expected fields tagged '-' to be skipped, but got: %s", err)
+	}
+
+	snapshot.Meta.Email = "invalid"
+	err := validator.Validate(snapshot)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Meta.Email" {
+		t.Errorf("Expected untagged nested struct to still be validated, but got: %v", err)
+	}
+}
+
+type Dependency struct {
+	Constraint string `validate:"semverrange"`
+}
+
+func TestSemverRange(t *testing.T) {
+	validator := New()
+
+	for _, constraint := range []string{
+		"^1.2.3",
+		"~1.2",
+		">=1.2.0 <2.0.0",
+		">= 1.2.0 < 2.0.0",
+		"1.2.3 - 2.3.4",
+		"1.x || >=2.5.0-beta.1",
+		"*",
+		"=v1.0.0",
+	} {
+		if err := validator.Validate(Dependency{Constraint: constraint}); err != nil {
+			t.Errorf("Expected '%s' to be a valid constraint, but got: %s", constraint, err)
+		}
+	}
+
+	for _, constraint := range []string{"", ">=", "^1.2.3.4", "01.2.3", "1.2.3 ||", "latest", "=>1.0.0"} {
+		err := validator.Validate(Dependency{Constraint: constraint})
+		if err == nil || validationMessage(err) != "invalid version constraint" {
+			t.Errorf("Expected '%s' to be rejected, but got: %v", constraint, err)
+		}
+	}
+}
+
+type CharacterClasses struct {
+	Name  string `validate:"alpha"`
+	PIN   string `validate:"numeric"`
+	Token string `validate:"alphanumeric"`
+}
+
+func TestCharacterClasses(t *testing.T) {
+	validator := New()
+
+	for _, value := range []CharacterClasses{
+		{Name: "José", PIN: "0042", Token: "abc123"},
+		{},
+	} {
+		if err := validator.Validate(value); err != nil {
+			t.Errorf("Expected %+v to pass, but got: %s", value, err)
+		}
+	}
+
+	tests := []struct {
+		value   CharacterClasses
+		message string
+	}{
+		{CharacterClasses{Name: "John2"}, "value must contain only letters"},
+		{CharacterClasses{Name: "John Doe"}, "value must contain only letters"},
+		{CharacterClasses{PIN: "12a4"}, "value must contain only digits"},
+		{CharacterClasses{PIN: "-12"}, "value must contain only digits"},
+		{CharacterClasses{Token: "abc-123"}, "value must contain only letters and digits"},
+	}
+	for _, test := range tests {
+		err := validator.Validate(test.value)
+		if err == nil || validationMessage(err) != test.message {
+			t.Errorf("Expected '%s' for %+v, but got: %v", test.message, test.value, err)
+		}
+	}
+}
+
+type Reading struct {
+	Sensor string
+	Value  float64
+}
+
+func TestValidateOutliers(t *testing.T) {
+	validator := New()
+	readings := []Reading{
+		{"a", 10}, {"b", 11}, {"c", 9}, {"d", 10.5}, {"e", 9.5},
+		{"f", 10}, {"g", 50}, {"h", 10.2}, {"i", 9.8}, {"j", 10.1},
+	}
+
+	errs := validator.ValidateOutliers(readings, "Value", 2)
+	if len(errs) != 1 {
+		t.Fatalf("Expected exactly one outlier, but got: %v", errs)
+	}
+	if validationErr, ok := errs[0].(*ValidationError); !ok || validationErr.Field != "[6].Value" || validationErr.Rule != "outlier" {
+		t.Errorf("Expected outlier at index 6, but got: %v", errs[0])
+	}
+
+	if errs := validator.ValidateOutliers(readings[:6], "Value", 2); len(errs) != 0 {
+		t.Errorf("Expected no outliers without the spike, but got: %v", errs)
+	}
+
+	if errs := validator.ValidateOutliers(readings, "Sensor", 2); len(errs) != 1 || !strings.Contains(errs[0].Error(), "not numeric") {
+		t.Errorf("Expected an error for a non-numeric field, but got: %v", errs)
+	}
+}
+
+type ContactDetails struct {
+	Phone string `validate:"required"`
+	Email string `validate:"omitempty,email"`
+}
+
+type Staff struct {
+	Name string `validate:"required"`
+	*ContactDetails
+}
+
+type Contractor struct {
+	Name            string `validate:"required"`
+	*ContactDetails `validate:"required"`
+}
+
+func TestEmbeddedPointerStruct(t *testing.T) {
+	validator := New()
+
+	if err := validator.Validate(Staff{Name: "Ann"}); err != nil {
+		t.Errorf("Expected a nil optional embedded struct to be skipped, but got: %s", err)
+	}
+
+	err := validator.Validate(Staff{Name: "Ann", ContactDetails: &ContactDetails{}})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Phone" || validationErr.Rule != "required" {
+		t.Errorf("Expected promoted Phone to be required, but got: %v", err)
+	}
+
+	err = validator.Validate(Contractor{Name: "Bob"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "ContactDetails" || validationErr.Rule != "required" {
+		t.Errorf("Expected a required nil embedded struct to fail, but got: %v", err)
+	}
+
+	err = validator.Validate(Contractor{Name: "Bob", ContactDetails: &ContactDetails{Phone: "555-0100", Email: "invalid"}})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Email" {
+		t.Errorf("Expected promoted Email error, but got: %v", err)
+	}
+
+	err = validator.ValidatePaths(Staff{Name: "", ContactDetails: &ContactDetails{}}, "Phone")
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "Phone" {
+		t.Errorf("Expected ValidatePaths to match promoted names, but got: %v", err)
+	}
+}
+
+type MalformedLimit struct {
+	Count int    `validate:"max=abc"`
+	Name  string `validate:"min=x,max=abc"`
+}
+
+func TestWithStrictTags(t *testing.T) {
+	if err := New().Validate(MalformedLimit{Count: 1000, Name: "Jane"}); err != nil {
+		t.Errorf("Expected malformed parameter to be ignored in lenient mode, but got: %s", err)
+	}
+
+	err := New().WithStrictTags(true).Validate(MalformedLimit{Count: 1000})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), `"max=abc"`) {
+		t.Errorf("Expected setup error for max=abc in strict mode, but got: %v", err)
+	}
+
+	type Fine struct {
+		Ratio  float64 `validate:"max=1.5"`
+		Digits int     `validate:"digits_between='2,4'"`
+	}
+	if err := New().WithStrictTags(true).Validate(Fine{Ratio: 1, Digits: 123}); err != nil {
+		t.Errorf("Expected well-formed parameters to pass in strict mode, but got: %s", err)
+	}
+}
+
+func TestStrictTagsCheckEveryParam(t *testing.T) {
+	type BadFlags struct {
+		Mode int `validate:"flags=1 two 4"`
+	}
+	type BadFits struct {
+		Count int64 `validate:"fits=int33"`
+	}
+	type BadSpan struct {
+		_     struct{}  `validate:"maxspan=Start End 30days"`
+		Start time.Time `validate:"-"`
+		End   time.Time `validate:"-"`
+	}
+	type EmptyOptional struct {
+		Limit int `validate:"omitempty,max=abc"`
+	}
+	type UnknownSibling struct {
+		Confirm string `validate:"eqfield=Pasword"`
+	}
+	type NestedBound struct {
+		Scores map[string][]float32 `validate:"dive,keys,min=x,endkeys"`
+	}
+	type BadDay struct {
+		Due time.Time `validate:"weekday=Mon Funday"`
+	}
+
+	cases := map[string]interface{}{
+		"flags=1 two 4":            BadFlags{},
+		"fits=int33":               BadFits{},
+		"maxspan=Start End 30days": BadSpan{},
+		"max=abc":                  EmptyOptional{},
+		"Pasword":                  UnknownSibling{},
+		"min=x":                    NestedBound{},
+		"weekday=Mon Funday":       BadDay{},
+	}
+	for param, value := range cases {
+		err := New().WithStrictTags(true).Validate(value)
+		if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), param) {
+			t.Errorf("Expected setup error mentioning %q for %T in strict mode, but got: %v", param, value, err)
+		}
+	}
+
+	type Dynamic struct {
+		Count interface{} `validate:"gt=3"`
+		Flag  interface{} `validate:"eq=true"`
+	}
+	strictErr := New().WithStrictTags(true).Validate(Dynamic{Count: 5, Flag: true})
+	lenientErr := New().Validate(Dynamic{Count: 5, Flag: true})
+	if !errors.Is(strictErr, ErrInvalidRule) || !errors.Is(lenientErr, ErrInvalidRule) {
+		t.Errorf("Expected comparisons on interface fields to be rejected in both modes, but got: %v / %v", strictErr, lenientErr)
+	}
+
+	type UnescapedComma struct {
+		Pin      int    `validate:"digits_between=4,8"`
+		IssuedOn string `validate:"datetime_any=2006-01-02|January 2, 2006"`
+	}
+	err := New().WithStrictTags(true).ValidateAll(UnescapedComma{Pin: 1234, IssuedOn: "2024-01-02"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 ||
+		!strings.Contains(errs[0].Error(), `"8" is not a rule; escape the comma in "digits_between=4,8"`) ||
+		!strings.Contains(errs[1].Error(), `" 2006" is not a rule; escape the comma in "datetime_any=2006-01-02|January 2, 2006"`) {
+		t.Errorf("Expected unescaped commas to be reported in strict mode, but got: %v", err)
+	}
+
+	if err := New().Validate(EmptyOptional{}); err != nil {
+		t.Errorf("Expected malformed parameter to be ignored in lenient mode, but got: %s", err)
+	}
+
+	err = New().Validate(BadFlags{Mode: 1})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected malformed flags to be a setup error in lenient mode, but got: %v", err)
+	}
+}
+
+type Misspelled struct {
+	Name string `validate:"requird,min=2"`
+}
+
+func TestStrictTagsUnknownRule(t *testing.T) {
+	if err := New().Validate(Misspelled{Name: "Al"}); err != nil {
+		t.Errorf("Expected unknown rule to be ignored in lenient mode, but got: %s", err)
+	}
+
+	err := New().WithStrictTags(true).Validate(Misspelled{Name: "Al"})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), `unknown validation rule "requird" on field Name`) {
+		t.Errorf("Expected unknown rule error in strict mode, but got: %v", err)
+	}
+
+	v := New().WithStrictTags(true)
+	v.RegisterRule("requird", func(fv reflect.Value, param string) error { return nil })
+	if err := v.Validate(Misspelled{Name: "Al"}); err != nil {
+		t.Errorf("Expected registered custom rule to be known in strict mode, but got: %s", err)
+	}
+
+	type Known struct {
+		Tags []string `validate:"omitempty,dive,alpha,min=1"`
+		ID   string   `validate:"uuid4"`
+	}
+	if err := New().WithStrictTags(true).Validate(Known{ID: "3f1c1e9e-8b5a-4c3d-9f2e-1a2b3c4d5e6f"}); err != nil {
+		t.Errorf("Expected built-in rules to be known in strict mode, but got: %s", err)
+	}
+
+	type Optional struct {
+		Nickname string  `validate:"omitempty,mn=3"`
+		Referrer *string `validate:"emial"`
+	}
+	err = New().WithStrictTags(true).Validate(Optional{})
+	if !errors.Is(err, ErrInvalidRule) || !strings.Contains(err.Error(), `unknown validation rule "mn" on field Nickname`) {
+		t.Errorf("Expected unknown rule on an empty optional field to be reported, but got: %v", err)
+	}
+
+	errs, ok := New().WithStrictTags(true).ValidateAll(Optional{}).(ValidationErrors)
+	if !ok || len(errs) != 2 || !strings.Contains(errs[1].Error(), `unknown validation rule "emial" on field Referrer`) {
+		t.Errorf("Expected unknown rule on a nil pointer to be reported, but got: %v", errs)
+	}
+}
+
+type Link struct {
+	URL   string `validate:"startswith=https://"`
+	Title string `validate:"contains='Go, fast'"`
+	File  string `validate:"endswith=.pdf"`
+	Note  string `validate:"contains=a\\,b"`
+}
+
+func TestSubstringRules(t *testing.T) {
+	validator := New()
+	valid := Link{URL: "https://example.com", Title: "Go, fast and simple", File: "guide.pdf", Note: "a,b,c"}
+
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Expected link to pass, but got: %s", err)
+	}
+
+	tests := []struct {
+		modify  func(*Link)
+		message string
+	}{
+		{func(l *Link) { l.URL = "http://example.com" }, "value must start with https://"},
+		{func(l *Link) { l.Title = "Go is fast" }, "value must contain Go, fast"},
+		{func(l *Link) { l.File = "guide.doc" }, "value must end with .pdf"},
+		{func(l *Link) { l.Note = "a b" }, "value must contain a,b"},
+	}
+	for _, test := range tests {
+		link := valid
+		test.modify(&link)
+		err := validator.Validate(link)
+		if err == nil || validationMessage(err) != test.message {
+			t.Errorf("Expected '%s', but got: %v", test.message, err)
+		}
+	}
+
+	err := validator.WithCustomErrors(CustomErrors{
+		"URL": {"startswith": "Links must use HTTPS"},
+	}).Validate(Link{URL: "ftp://example.com", Title: valid.Title, File: valid.File, Note: valid.Note})
+	if err == nil || validationMessage(err) != "Links must use HTTPS" {
+		t.Errorf("Expected custom startswith message, but got: %v", err)
+	}
+}
+
+type PostalAddress struct {
+	Country string
+	Zip     string `validate:"postcode=Country"`
+}
+
+func TestPostcode(t *testing.T) {
+	validator := New()
+
+	for _, address := range []PostalAddress{
+		{"US", "94105"},
+		{"US", "94105-1234"},
+		{"UK", "SW1A 1AA"},
+		{"gb", "m1 1ae"},
+		{"ZZ", "anything"},
+	} {
+		if err := validator.Validate(address); err != nil {
+			t.Errorf("Expected %+v to pass, but got: %s", address, err)
+		}
+	}
+
+	err := validator.Validate(PostalAddress{"UK", "12345"})
+	if err == nil || validationMessage(err) != "invalid postal code for UK" {
+		t.Errorf("Expected invalid UK postcode error, but got: %v", err)
+	}
+
+	err = validator.Validate(PostalAddress{"US", "9410"})
+	if err == nil || validationMessage(err) != "invalid postal code for US" {
+		t.Errorf("Expected invalid US zip error, but got: %v", err)
+	}
+
+	type Broken struct {
+		Zip string `validate:"postcode=Country"`
+	}
+	if err := validator.Validate(Broken{Zip: "94105"}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected setup error for a missing country field, but got: %v", err)
+	}
+}